@@ -4,25 +4,88 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"runtime/debug"
+	"strconv"
+	"time"
 
+	"github.com/tomz197/asteroids/internal/config"
+	"github.com/tomz197/asteroids/internal/draw"
+	"github.com/tomz197/asteroids/internal/input"
 	"github.com/tomz197/asteroids/internal/loop"
 	"github.com/tomz197/asteroids/internal/loop/client"
+	loopconfig "github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/loop/server"
+	"github.com/tomz197/asteroids/internal/object"
 	"golang.org/x/term"
 )
 
+// version is the build version, set at build time with
+// -ldflags "-X main.version=...".
+var version = "dev"
+
 func main() {
+	// FPS lowers the frame rate on slow hosts (e.g. a Raspberry Pi)
+	fps := loopconfig.ClientTargetFPS
+	if v := os.Getenv("FPS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < loopconfig.ClientMinFPS || n > loopconfig.ClientMaxFPS {
+			fmt.Fprintf(os.Stderr, "FPS must be a number from %d to %d, got %q\n", loopconfig.ClientMinFPS, loopconfig.ClientMaxFPS, v)
+			os.Exit(1)
+		}
+		fps = n
+	}
+
 	fd := int(os.Stdin.Fd())
 	oldState, err := term.MakeRaw(fd)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to enable raw mode: %v\n", err)
 		os.Exit(1)
 	}
-	defer func() {
+	// Restore the terminal on every exit path, including a panic in the game
+	// loop: reset it first so the error or stack trace prints into a usable
+	// shell rather than a raw, cursorless screen.
+	restore := func() {
+		draw.ResetTerminal(os.Stdout)
 		_ = term.Restore(fd, oldState)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			restore()
+			fmt.Fprintf(os.Stderr, "game crashed: %v\n\n%s", r, debug.Stack())
+			os.Exit(2)
+		}
+		restore()
 	}()
 
+	colorLevel := draw.DetectColorLevel(os.Getenv("TERM"), os.Getenv("COLORTERM"))
+	if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+		colorLevel = draw.ColorLevelNone
+	}
+	particleStyle, _ := object.ParticleStyleByName(os.Getenv("PARTICLE_STYLE"))
+	bulletStyle, _ := object.ProjectileStyleByName(os.Getenv("PROJECTILE_STYLE"))
+	minimap, _ := client.ParseMinimapOptions(os.Getenv("MINIMAP_SIZE"), os.Getenv("MINIMAP_CORNER"))
+	deadzone, _ := client.ParseCameraDeadzone(os.Getenv("CAMERA_DEADZONE"))
+	keyHold, _ := input.ParseHoldDurations(os.Getenv("KEY_HOLD"), os.Getenv("FIRE_HOLD"))
+	quadrant, _ := server.QuadrantByName(os.Getenv("SPAWN_QUADRANT"))
+	opts := client.ClientOptions{
+		Theme:         os.Getenv("THEME"),
+		ColorLevel:    colorLevel,
+		Scanlines:     config.GetEnvBool("CRT", false),
+		ASCII:         config.GetEnvBool("ASCII", false),
+		Braille:       config.GetEnvBool("BRAILLE", false),
+		Version:       version,
+		ParticleStyle: particleStyle,
+		BulletStyle:   bulletStyle,
+		FrameTime:     time.Second / time.Duration(fps),
+		Minimap:       minimap,
+		Deadzone:      deadzone,
+		KeyHold:       keyHold,
+		SpawnQuadrant: quadrant,
+	}
+
 	reader := bufio.NewReader(os.Stdin)
-	if err := loop.RunClientServer(reader, os.Stdout, client.ClientOptions{}); err != nil {
+	if err := loop.RunClientServer(reader, os.Stdout, opts); err != nil {
+		restore()
 		fmt.Fprintf(os.Stderr, "game error: %v\n", err)
 		os.Exit(1)
 	}
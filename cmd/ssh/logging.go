@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// setupLogging installs a structured slog logger at the level named by the
+// LOG_LEVEL env var (error, warn, info or debug; default info) and routes
+// the standard log package through it at info level. Per-session logs are
+// debug, so the default level keeps usernames and terminal details out.
+func setupLogging(levelName string) error {
+	level, err := parseLogLevel(levelName)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+	return nil
+}
+
+// parseLogLevel maps a LOG_LEVEL value to a slog level.
+func parseLogLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("invalid LOG_LEVEL %q: must be error, warn, info or debug", name)
+}
+
+// debugLogger returns a log.Logger writing at debug level, for libraries
+// that take a Printf-style logger (like wish's connection logging).
+func debugLogger() *log.Logger {
+	return slog.NewLogLogger(slog.Default().Handler(), slog.LevelDebug)
+}
+
+// fatal logs msg at error level and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -3,19 +3,25 @@ package main
 import (
 	"bufio"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
-	"unicode"
 
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
@@ -23,9 +29,12 @@ import (
 	"github.com/charmbracelet/wish/logging"
 	"github.com/tomz197/asteroids/internal/config"
 	"github.com/tomz197/asteroids/internal/draw"
+	"github.com/tomz197/asteroids/internal/input"
 	"github.com/tomz197/asteroids/internal/loop/client"
 	loopconfig "github.com/tomz197/asteroids/internal/loop/config"
 	"github.com/tomz197/asteroids/internal/loop/server"
+	"github.com/tomz197/asteroids/internal/object"
+	gossh "golang.org/x/crypto/ssh"
 
 	_ "net/http/pprof"
 )
@@ -36,16 +45,37 @@ const (
 	defaultHostKeyPath = "/app/keys/host_key"
 )
 
+// version is the build version, set at build time with
+// -ldflags "-X main.version=...".
+var version = "dev"
+
 // Global game server - shared by all SSH clients
 var (
 	gameServer   *server.Server
 	cancelServer context.CancelFunc
 	serverOnce   sync.Once
+	uiTheme      string                 // Built-in UI theme applied to every session
+	crtMode      bool                   // CRT scanline rendering for every session
+	asciiMode    bool                   // ASCII canvas characters for every session
+	brailleMode  bool                   // Braille canvas cells for every session
+	maxFrameSize int                    // Canvas byte budget per frame for every session (0 = unlimited)
+	particleLook object.ParticleStyle   // How particles are drawn for every session
+	bulletLook   object.ProjectileStyle // How projectiles are drawn for every session
+	minimapOpts  client.MinimapOptions  // Minimap size and corner for every session
+	deadzone     client.CameraDeadzone  // Camera deadzone for every session
+	keyHold      input.HoldDurations    // How long keys count as held for every session
+	motdLines    []string               // Banner shown before the game (nil = none)
+	motdTimeout  time.Duration          // How long the banner waits for a keypress
 )
 
 func main() {
-	if err := config.LoadEnvFile(".env"); err != nil {
-		log.Printf("Warning: failed to load .env file: %v", err)
+	envErr := config.LoadEnvFile(".env")
+	if err := setupLogging(config.GetEnv("LOG_LEVEL", "")); err != nil {
+		fatal("logging setup failed", "err", err)
+	}
+	slog.Info("asteroids SSH server", "version", version, "go", runtime.Version())
+	if envErr != nil {
+		slog.Warn("failed to load .env file", "err", envErr)
 	}
 
 	host := config.GetEnv("SSH_HOST", defaultHost)
@@ -53,18 +83,76 @@ func main() {
 	hostKeyPath := config.GetEnv("SSH_HOST_KEY", defaultHostKeyPath)
 	workingDir, workErr := os.Getwd()
 	if workErr != nil {
-		log.Printf("Failed to get working directory: %v", workErr)
+		slog.Warn("failed to get working directory", "err", workErr)
+	}
+	slog.Info("SSH config", "host", host, "port", port, "hostKeyPath", hostKeyPath, "workingDir", workingDir)
+
+	uiTheme = config.GetEnv("THEME", "")
+	if _, ok := client.ThemeByName(uiTheme); uiTheme != "" && !ok {
+		slog.Warn("unknown THEME, using default", "theme", uiTheme)
+	}
+	crtMode = config.GetEnvBool("CRT", false)
+	asciiMode = config.GetEnvBool("ASCII", false)
+	brailleMode = config.GetEnvBool("BRAILLE", false)
+	if v := config.GetEnv("PARTICLE_STYLE", ""); v != "" {
+		style, ok := object.ParticleStyleByName(v)
+		if !ok {
+			slog.Warn("unknown PARTICLE_STYLE, using dots", "style", v)
+		}
+		particleLook = style
+	}
+	if v := config.GetEnv("PROJECTILE_STYLE", ""); v != "" {
+		style, ok := object.ProjectileStyleByName(v)
+		if !ok {
+			slog.Warn("unknown PROJECTILE_STYLE, using dot", "style", v)
+		}
+		bulletLook = style
+	}
+	mm, err := client.ParseMinimapOptions(config.GetEnv("MINIMAP_SIZE", ""), config.GetEnv("MINIMAP_CORNER", ""))
+	if err != nil {
+		fatal("invalid minimap config", "err", err)
+	}
+	minimapOpts = mm
+	deadzone, err = client.ParseCameraDeadzone(config.GetEnv("CAMERA_DEADZONE", ""))
+	if err != nil {
+		fatal("invalid CAMERA_DEADZONE", "err", err)
+	}
+	keyHold, err = input.ParseHoldDurations(config.GetEnv("KEY_HOLD", ""), config.GetEnv("FIRE_HOLD", ""))
+	if err != nil {
+		fatal("invalid KEY_HOLD or FIRE_HOLD", "err", err)
+	}
+	if v := config.GetEnv("MAX_FRAME_BYTES", ""); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			fatal("invalid MAX_FRAME_BYTES: must be a non-negative integer", "value", v)
+		}
+		maxFrameSize = n
+	}
+	lines, err := loadMOTD()
+	if err != nil {
+		fatal("failed to load MOTD", "err", err)
+	}
+	motdLines = lines
+	motdTimeout = defaultMOTDTimeout
+	if v := config.GetEnv("MOTD_TIMEOUT", ""); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			fatal("invalid MOTD_TIMEOUT: must be a positive duration like 10s", "value", v)
+		}
+		motdTimeout = d
+	}
+	if len(motdLines) > 0 {
+		slog.Info("MOTD banner enabled", "lines", len(motdLines), "timeout", motdTimeout)
 	}
-	log.Printf("SSH config: host=%s port=%s hostKeyPath=%s workingDir=%s", host, port, hostKeyPath, workingDir)
 
 	// Initialize pprof server (dev only)
 	// if config.GetEnv("ENV", "") == "dev" {
 	runtime.SetMutexProfileFraction(5)
 	runtime.SetBlockProfileRate(1)
 	go func() {
-		log.Println("Pprof server starting on :6060")
+		slog.Info("pprof server starting", "addr", ":6060")
 		if err := http.ListenAndServe(":6060", nil); err != nil {
-			log.Printf("Pprof server error: %v", err)
+			slog.Error("pprof server error", "err", err)
 		}
 	}()
 	// }
@@ -73,17 +161,26 @@ func main() {
 	serverOnce.Do(func() {
 		var ctx context.Context
 		ctx, cancelServer = context.WithCancel(context.Background())
-		gameServer = server.NewServer()
+		var err error
+		gameServer, err = server.NewServer()
+		if err != nil {
+			fatal("failed to create game server", "err", err)
+		}
 		go gameServer.Run(ctx)
-		log.Println("Game server started")
+		slog.Info("game server started")
 	})
 
+	// Optional status endpoint for the web landing page
+	if statusAddr := config.GetEnv("STATUS_ADDR", ""); statusAddr != "" {
+		go serveStatus(statusAddr)
+	}
+
 	opts := []ssh.Option{
 		wish.WithAddress(net.JoinHostPort(host, port)),
 		wish.WithMiddleware(
 			gameMiddleware,
 			activeterm.Middleware(),
-			logging.Middleware(),
+			logging.MiddlewareWithLogger(debugLogger()),
 		),
 		// Set TCP_NODELAY to reduce latency for game input
 		ssh.WrapConn(func(ctx ssh.Context, conn net.Conn) net.Conn {
@@ -95,40 +192,44 @@ func main() {
 	}
 
 	if hostKeyPath != "" {
-		opts = append(opts, wish.WithHostKeyPath(hostKeyPath))
+		keyPEM, err := loadOrCreateHostKey(hostKeyPath)
+		if err != nil {
+			fatal("failed to load host key", "err", err)
+		}
+		opts = append(opts, wish.WithHostKeyPEM(keyPEM))
 	}
 
 	s, err := wish.NewServer(opts...)
 	if err != nil {
-		log.Fatalf("failed to create server: %v", err)
+		fatal("failed to create SSH server", "err", err)
 	}
 
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
-	log.Printf("Starting SSH server on %s:%s", host, port)
+	slog.Info("starting SSH server", "host", host, "port", port)
 	go func() {
 		if err := s.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
-			log.Fatalf("server error: %v", err)
+			fatal("SSH server error", "err", err)
 		}
 	}()
 
 	<-done
-	log.Println("Shutting down server...")
+	slog.Info("shutting down server")
 
 	// Gracefully shut down the game server: notify players and wait for them to disconnect
 	if gameServer != nil {
-		log.Println("Notifying connected players about shutdown...")
+		slog.Info("notifying connected players about shutdown")
 		gameServer.Shutdown(15 * time.Second)
 		cancelServer()
-		log.Println("Game server stopped")
+		slog.Info("game server stopped")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := s.Shutdown(ctx); err != nil {
-		log.Fatalf("shutdown error: %v", err)
+		fatal("shutdown error", "err", err)
 	}
 }
 
@@ -141,8 +242,8 @@ func gameMiddleware(next ssh.Handler) ssh.Handler {
 			return
 		}
 
-		log.Printf("New game session: user=%s, terminal=%s, size=%dx%d",
-			sess.User(), pty.Term, pty.Window.Width, pty.Window.Height)
+		slog.Debug("session started", "user", sess.User(), "term", pty.Term,
+			"width", pty.Window.Width, "height", pty.Window.Height)
 
 		// Create a terminal size tracker that updates on window changes
 		sizeTracker := newSizeTracker(pty.Window.Width, pty.Window.Height)
@@ -154,24 +255,139 @@ func gameMiddleware(next ssh.Handler) ssh.Handler {
 			}
 		}()
 
-		reader := bufio.NewReader(sess)
+		// Round-trip time shown on the HUD
+		rtt := trackRTT(sess)
+
+		// Optional message of the day, dismissed by a key or the timeout
+		var input io.Reader = sess
+		if len(motdLines) > 0 {
+			input = showMOTD(sess, motdLines, motdTimeout)
+		}
+
+		// Players may pick a spawn quadrant with SetEnv (unknown names mean anywhere)
+		quadrant, _ := server.QuadrantByName(sessionEnv(sess, "SPAWN_QUADRANT"))
+
+		reader := bufio.NewReader(input)
 		clientOpts := client.ClientOptions{
-			TermSizeFunc: sizeTracker.getSize,
-			Username:     sanitizeUsername(sess.User()),
+			TermSizeFunc:  sizeTracker.getSize,
+			Username:      server.SanitizeUsername(sess.User()),
+			Theme:         uiTheme,
+			ColorLevel:    draw.DetectColorLevel(pty.Term, sessionEnv(sess, "COLORTERM")),
+			Scanlines:     crtMode,
+			ASCII:         asciiMode,
+			Braille:       brailleMode,
+			MaxFrameBytes: maxFrameSize,
+			Version:       version,
+			ParticleStyle: particleLook,
+			BulletStyle:   bulletLook,
+			Minimap:       minimapOpts,
+			Deadzone:      deadzone,
+			KeyHold:       keyHold,
+			PingFunc:      rtt.get,
+			SpawnQuadrant: quadrant,
 		}
 
 		// Create a new client connected to the shared game server
 		c := client.NewClient(gameServer, reader, sess, clientOpts)
 		if err := c.Run(); err != nil {
-			log.Printf("Game error for %s: %v", sess.User(), err)
+			slog.Error("game error", "user", sess.User(), "err", err)
 		}
 
-		log.Printf("Session ended: user=%s", sess.User())
+		slog.Debug("session ended", "user", sess.User())
 		next(sess)
 	}
 }
 
+// loadOrCreateHostKey returns the PEM-encoded host key at path, generating and
+// persisting a new ed25519 key if none exists so the server fingerprint stays
+// stable across restarts (and clients' known_hosts entries remain valid).
+// Logs the key's SHA256 fingerprint.
+func loadOrCreateHostKey(path string) ([]byte, error) {
+	keyPEM, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		if keyPEM, err = generateHostKey(path); err != nil {
+			return nil, err
+		}
+		slog.Info("generated new host key", "path", path)
+	case errors.Is(err, os.ErrPermission):
+		return nil, fmt.Errorf("cannot read %s: permission denied (check SSH_HOST_KEY and file ownership)", path)
+	default:
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	signer, err := gossh.ParsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	slog.Info("host key loaded", "fingerprint", gossh.FingerprintSHA256(signer.PublicKey()))
+	return keyPEM, nil
+}
+
+// generateHostKey creates an ed25519 host key, writes it to path with
+// owner-only permissions (creating the parent directory if needed) and
+// returns it PEM-encoded.
+func generateHostKey(path string) ([]byte, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+	block, err := gossh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		return nil, fmt.Errorf("encode key: %w", err)
+	}
+	keyPEM := pem.EncodeToMemory(block)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return nil, fmt.Errorf("cannot create directory for %s: permission denied (check SSH_HOST_KEY)", path)
+		}
+		return nil, fmt.Errorf("create directory for %s: %w", path, err)
+	}
+	// O_EXCL so a key created concurrently by another process is never clobbered
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return nil, fmt.Errorf("cannot write %s: permission denied (check SSH_HOST_KEY and directory ownership)", path)
+		}
+		return nil, fmt.Errorf("create %s: %w", path, err)
+	}
+	if _, err := f.Write(keyPEM); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("close %s: %w", path, err)
+	}
+	return keyPEM, nil
+}
+
+// serveStatus serves the live player count and render egress as JSON at
+// /status. It listens on its own mux so the endpoint is independent of pprof.
+func serveStatus(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		stats := gameServer.Stats()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Players           int   `json:"players"`
+			RenderBytes       int64 `json:"render_bytes"`
+			RenderBytesPerSec int64 `json:"render_bytes_per_sec"`
+			ChurnPerSec       int64 `json:"churn_per_sec"`
+		}{stats.Players, stats.RenderBytes, stats.RenderBytesPerSec, stats.ChurnPerSec})
+	})
+	mux.HandleFunc("/overview", handleOverview)
+	mux.HandleFunc("/map.svg", handleMap)
+	slog.Info("status endpoint listening", "url", "http://"+addr+"/status")
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		slog.Error("status endpoint error", "err", err)
+	}
+}
+
 // sizeTracker tracks terminal size from SSH window change events.
+// Some clients report 0x0 during negotiation; the tracker starts at a
+// default size and ignores invalid updates until a real size arrives.
 type sizeTracker struct {
 	mu     sync.RWMutex
 	width  int
@@ -179,10 +395,15 @@ type sizeTracker struct {
 }
 
 func newSizeTracker(width, height int) *sizeTracker {
-	return &sizeTracker{width: width, height: height}
+	s := &sizeTracker{width: loopconfig.DefaultTermWidth, height: loopconfig.DefaultTermHeight}
+	s.update(width, height)
+	return s
 }
 
 func (s *sizeTracker) update(width, height int) {
+	if width <= 0 || height <= 0 {
+		return // Keep the last good size
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.width = width
@@ -198,21 +419,13 @@ func (s *sizeTracker) getSize() (int, int, error) {
 // Ensure sizeTracker.getSize satisfies draw.TermSizeFunc
 var _ draw.TermSizeFunc = (*sizeTracker)(nil).getSize
 
-// sanitizeUsername strips control characters and escape sequences from a username
-// to prevent terminal injection attacks, then caps it to maxUsernameLength runes.
-func sanitizeUsername(raw string) string {
-	var b strings.Builder
-	b.Grow(len(raw))
-	count := 0
-	for _, r := range raw {
-		if !unicode.IsGraphic(r) {
-			continue
-		}
-		if count >= loopconfig.MaxUsernameLength {
-			break
+// sessionEnv returns the value of an environment variable sent by the SSH client,
+// or "" if it was not sent.
+func sessionEnv(sess ssh.Session, key string) string {
+	for _, kv := range sess.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok && k == key {
+			return v
 		}
-		b.WriteRune(r)
-		count++
 	}
-	return strings.TrimSpace(b.String())
+	return ""
 }
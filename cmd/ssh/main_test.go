@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	loopconfig "github.com/tomz197/asteroids/internal/loop/config"
+)
+
+func TestSizeTrackerIgnoresZeroSize(t *testing.T) {
+	s := newSizeTracker(0, 0)
+	w, h, _ := s.getSize()
+	if w != loopconfig.DefaultTermWidth || h != loopconfig.DefaultTermHeight {
+		t.Errorf("size before a real one arrived = %dx%d, want the default %dx%d",
+			w, h, loopconfig.DefaultTermWidth, loopconfig.DefaultTermHeight)
+	}
+
+	s.update(120, 40)
+	if w, h, _ = s.getSize(); w != 120 || h != 40 {
+		t.Errorf("size = %dx%d after a 120x40 update, want 120x40", w, h)
+	}
+
+	// A later invalid report keeps the last good size
+	for _, size := range [][2]int{{0, 0}, {0, 40}, {120, -1}} {
+		s.update(size[0], size[1])
+		if w, h, _ = s.getSize(); w != 120 || h != 40 {
+			t.Errorf("size = %dx%d after a %dx%d update, want 120x40 kept", w, h, size[0], size[1])
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/tomz197/asteroids/internal/config"
+)
+
+const (
+	defaultMOTDTimeout = 10 * time.Second // How long the banner waits for a keypress
+	maxMOTDLines       = 20               // Banner lines kept; the rest is dropped
+	maxMOTDWidth       = 76               // Runes kept per banner line
+)
+
+// loadMOTD returns the sanitized message-of-the-day lines from MOTD_FILE, or
+// from the MOTD env var when no file is set. Returns nil when neither is set,
+// which disables the banner.
+func loadMOTD() ([]string, error) {
+	text := config.GetEnv("MOTD", "")
+	if path := config.GetEnv("MOTD_FILE", ""); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read MOTD_FILE: %w", err)
+		}
+		text = string(data)
+	}
+	return sanitizeMOTD(text), nil
+}
+
+// sanitizeMOTD splits text into lines with escape sequences and control
+// characters removed (tabs become spaces), capped to maxMOTDLines lines of
+// maxMOTDWidth runes. Trailing blank lines are dropped.
+func sanitizeMOTD(text string) []string {
+	var lines []string
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if len(lines) >= maxMOTDLines {
+			break
+		}
+		var b strings.Builder
+		count := 0
+		inEscape := false
+		for _, r := range raw {
+			if inEscape {
+				// Skip up to the final byte of a CSI sequence ("\033[...m")
+				if r >= 0x40 && r <= 0x7e && r != '[' {
+					inEscape = false
+				}
+				continue
+			}
+			if r == '\033' {
+				inEscape = true
+				continue
+			}
+			if r == '\t' {
+				r = ' '
+			}
+			if !unicode.IsGraphic(r) || count >= maxMOTDWidth {
+				continue
+			}
+			b.WriteRune(r)
+			count++
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// showMOTD writes the banner and waits for a keypress, the timeout, or the
+// session closing. The key that dismisses the banner is consumed. On timeout
+// the pending read is handed to the returned reader, so the first key pressed
+// in the game still reaches it; read session input through it from then on.
+func showMOTD(sess ssh.Session, lines []string, timeout time.Duration) io.Reader {
+	var b strings.Builder
+	b.WriteString("\033[2J\033[H")
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\r\n")
+	}
+	b.WriteString("\r\nPress any key to continue...")
+	if _, err := io.WriteString(sess, b.String()); err != nil {
+		return sess
+	}
+
+	pending := make(chan pendingRead, 1)
+	go func() {
+		var buf [1]byte
+		n, err := sess.Read(buf[:])
+		pending <- pendingRead{b: buf[0], n: n, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-pending:
+		return sess
+	case <-sess.Context().Done():
+		return sess
+	case <-timer.C:
+		return &pendingReader{pending: pending, r: sess}
+	}
+}
+
+// pendingRead is the result of a single-byte read started by showMOTD.
+type pendingRead struct {
+	b   byte
+	n   int
+	err error
+}
+
+// pendingReader returns the result of an in-flight read before reading
+// from r directly.
+type pendingReader struct {
+	pending chan pendingRead
+	r       io.Reader
+}
+
+func (p *pendingReader) Read(buf []byte) (int, error) {
+	if p.pending != nil {
+		res := <-p.pending
+		p.pending = nil
+		if res.n > 0 && len(buf) > 0 {
+			buf[0] = res.b
+			return 1, res.err
+		}
+		if res.err != nil {
+			return 0, res.err
+		}
+	}
+	return p.r.Read(buf)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+
+	"github.com/tomz197/asteroids/internal/loop/server"
+)
+
+// handleOverview serves the world overview as JSON, for dashboards that draw
+// their own map.
+func handleOverview(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(gameServer.Overview())
+}
+
+// handleMap serves the world overview as a top-down SVG map: asteroid
+// density as shaded cells, ships as labeled dots.
+func handleMap(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-store")
+	writeMapSVG(w, gameServer.Overview())
+}
+
+// writeMapSVG renders ov as an SVG document in world coordinates.
+func writeMapSVG(w io.Writer, ov server.WorldOverview) {
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d">`,
+		ov.Width, ov.Height, ov.Width, ov.Height)
+	fmt.Fprintf(w, `<rect width="%d" height="%d" fill="#000"/>`, ov.Width, ov.Height)
+
+	// Shade each density cell relative to the busiest one
+	busiest := 0
+	for _, row := range ov.Density {
+		for _, n := range row {
+			busiest = max(busiest, n)
+		}
+	}
+	rows := len(ov.Density)
+	cellW := float64(ov.Width) / float64(rows)
+	cellH := float64(ov.Height) / float64(rows)
+	for r, row := range ov.Density {
+		for c, n := range row {
+			if n == 0 {
+				continue
+			}
+			fmt.Fprintf(w, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#888" fill-opacity="%.2f"/>`,
+				float64(c)*cellW, float64(r)*cellH, cellW, cellH, 0.1+0.6*float64(n)/float64(busiest))
+		}
+	}
+
+	for _, p := range ov.Players {
+		fmt.Fprintf(w, `<circle cx="%.1f" cy="%.1f" r="3" fill="#0f0"/>`, p.X, p.Y)
+		fmt.Fprintf(w, `<text x="%.1f" y="%.1f" fill="#0f0" font-size="8" font-family="monospace" text-anchor="middle">%s</text>`,
+			p.X, p.Y-5, html.EscapeString(p.Name))
+	}
+	io.WriteString(w, "</svg>")
+}
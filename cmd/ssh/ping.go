@@ -0,0 +1,52 @@
+package main
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// pingInterval is how often a session's round-trip time is measured.
+const pingInterval = 2 * time.Second
+
+// rttTracker measures a session's round-trip time with SSH keepalive
+// requests. Clients answer them at the protocol level (with a failure reply,
+// as for any unknown global request), so no cooperation from the player's
+// terminal is needed.
+type rttTracker struct {
+	rtt atomic.Int64 // Latest measurement in nanoseconds; 0 until the first reply
+}
+
+// trackRTT starts measuring sess's round-trip time until the session ends.
+func trackRTT(sess ssh.Session) *rttTracker {
+	t := &rttTracker{}
+	conn, ok := sess.Context().Value(ssh.ContextKeyConn).(gossh.Conn)
+	if !ok {
+		return t
+	}
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			start := time.Now()
+			// Blocks until the reply arrives; fails once the connection closes
+			if _, _, err := conn.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+				return
+			}
+			t.rtt.Store(int64(time.Since(start)))
+			select {
+			case <-sess.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return t
+}
+
+// get returns the latest round-trip time, or 0 if none was measured yet.
+func (t *rttTracker) get() time.Duration {
+	return time.Duration(t.rtt.Load())
+}
@@ -2,10 +2,14 @@ package main
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tomz197/asteroids/internal/config"
 )
@@ -13,8 +17,15 @@ import (
 const (
 	defaultHost = "0.0.0.0"
 	defaultPort = "8080"
+
+	statusCacheTime = 5 * time.Second // How long a fetched player count is reused
+	statusTimeout   = 2 * time.Second // Timeout for querying the game server status
 )
 
+// version is the build version, set at build time with
+// -ldflags "-X main.version=...".
+var version = "dev"
+
 //go:embed index.html
 var htmlPage string
 
@@ -26,16 +37,77 @@ func main() {
 	host := config.GetEnv("WEB_HOST", defaultHost)
 	port := config.GetEnv("WEB_PORT", defaultPort)
 	sshHost := config.GetEnv("SSH_DISPLAY_HOST", "your-server.com")
+	status := newStatusFetcher(config.GetEnv("STATUS_URL", ""))
 
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		players := ""
+		if n, ok := status.players(); ok {
+			players = strconv.Itoa(n)
+		}
 		page := strings.Replace(htmlPage, "{{.SSHHost}}", sshHost, -1)
+		page = strings.Replace(page, "{{.Players}}", players, -1)
+		page = strings.Replace(page, "{{.Version}}", version, -1)
 		fmt.Fprint(w, page)
 	})
 
 	addr := fmt.Sprintf("%s:%s", host, port)
+	log.Printf("Asteroids web server version %s", version)
 	log.Printf("Starting web server on http://%s", addr)
 	if err := http.ListenAndServe(addr, nil); err != nil {
 		log.Fatalf("server error: %v", err)
 	}
 }
+
+// statusFetcher queries the game server's status endpoint for the live
+// player count, caching the result briefly so page views don't each hit it.
+type statusFetcher struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	count     int
+	ok        bool
+	fetchedAt time.Time
+}
+
+func newStatusFetcher(url string) *statusFetcher {
+	return &statusFetcher{url: url, client: &http.Client{Timeout: statusTimeout}}
+}
+
+// players returns the cached player count, refreshing it when stale.
+// Returns false when no status URL is configured or the server is unreachable.
+func (s *statusFetcher) players() (int, bool) {
+	if s.url == "" {
+		return 0, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.fetchedAt) < statusCacheTime {
+		return s.count, s.ok
+	}
+	s.fetchedAt = time.Now()
+	s.count, s.ok = s.fetch()
+	return s.count, s.ok
+}
+
+func (s *statusFetcher) fetch() (int, bool) {
+	resp, err := s.client.Get(s.url)
+	if err != nil {
+		log.Printf("Status fetch failed: %v", err)
+		return 0, false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Status fetch failed: %s", resp.Status)
+		return 0, false
+	}
+	var status struct {
+		Players int `json:"players"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		log.Printf("Status fetch failed: %v", err)
+		return 0, false
+	}
+	return status.Players, true
+}
@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -63,3 +64,14 @@ func GetEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// GetEnvBool returns the environment variable named by the key parsed as a
+// boolean ("1", "true", "0", "false", ...), or fallback if it is unset or invalid.
+func GetEnvBool(key string, fallback bool) bool {
+	if value, ok := os.LookupEnv(key); ok {
+		if b, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // ANSI color codes for terminal output.
@@ -32,10 +33,49 @@ const (
 	ColorBrightWhite   = "\033[97m"
 
 	// Semantic colors for UI elements
-	ColorDim = "\033[2m" // Dimmed text
+	ColorDim     = "\033[2m"  // Dimmed text
+	ColorNormal  = "\033[22m" // Normal intensity (ends ColorDim without resetting colors)
+	ColorDefault = "\033[39m" // Default foreground (ends a color without resetting intensity)
 )
 
+// ColorLevel describes how many colors a terminal can display.
+type ColorLevel int
+
+const (
+	ColorLevelNone ColorLevel = iota // No color support; only default attributes
+	ColorLevel16                     // Standard and bright ANSI colors (30–37, 90–97)
+	ColorLevel256                    // xterm 256-color palette
+	ColorLevelTrue                   // 24-bit truecolor
+)
+
+// DetectColorLevel guesses the color support of a terminal from its TERM and
+// COLORTERM values. Unknown terminals are assumed to support the 16 ANSI colors.
+func DetectColorLevel(termName, colorTerm string) ColorLevel {
+	switch {
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return ColorLevelTrue
+	case termName == "" || termName == "dumb":
+		return ColorLevelNone
+	case strings.Contains(termName, "256color"):
+		return ColorLevel256
+	default:
+		return ColorLevel16
+	}
+}
+
+// Color256 returns the SGR foreground sequence for an xterm 256-color palette index.
+func Color256(n uint8) string {
+	return "\033[38;5;" + strconv.Itoa(int(n)) + "m"
+}
+
+// TrueColor returns the SGR foreground sequence for a 24-bit RGB color.
+func TrueColor(r, g, b uint8) string {
+	return "\033[38;2;" + strconv.Itoa(int(r)) + ";" + strconv.Itoa(int(g)) + ";" + strconv.Itoa(int(b)) + "m"
+}
+
 // cellState represents the visual state of a terminal cell for double-buffering.
+// Half-block cells use the constants below; Braille cells store their dot
+// pattern (the offset from BrailleBlank), where 0 is likewise empty.
 type cellState byte
 
 const (
@@ -45,27 +85,74 @@ const (
 	cellFull                   // '█'
 )
 
-// prevCells packing: low 2 bits = cell state, bit 2 = dirty from MarkTextDirty.
+// asciiCells maps cell states to their ASCII fallback characters.
+var asciiCells = [4]byte{
+	cellEmpty: ' ',
+	cellUpper: ASCIIUpperHalf,
+	cellLower: ASCIILowerHalf,
+	cellFull:  ASCIIFull,
+}
+
+// prevCells packing: low 8 bits = cell state, bit 8 = dirty from MarkTextDirty.
+const (
+	cellStateMask = 0xff
+	cellDirtyBit  = 0x100
+)
+
+// brailleDots maps a sub-pixel's [row][column] within a Braille cell to its
+// dot bit in the pattern.
+var brailleDots = [4][2]cellState{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Dots of the upper and lower two sub-pixel rows of a Braille cell.
 const (
-	cellStateMask = 0x03
-	cellDirtyBit  = 0x04
+	brailleUpperDots = 0x01 | 0x08 | 0x02 | 0x10
+	brailleLowerDots = 0x04 | 0x20 | 0x40 | 0x80
 )
 
-// Canvas is a drawing buffer with 2x vertical resolution using half-block characters.
+// brailleHalves reduces a Braille pattern to the half-block state covering
+// the same rows, for drawing it in ASCII mode.
+func brailleHalves(pattern cellState) cellState {
+	var s cellState
+	if pattern&brailleUpperDots != 0 {
+		s |= cellUpper
+	}
+	if pattern&brailleLowerDots != 0 {
+		s |= cellLower
+	}
+	return s
+}
+
+// maxAccents is how many distinct accent colors one frame can use.
+const maxAccents = 255
+
+// maxRGBColors caps the SetColored sequence cache; it is dropped when full.
+const maxRGBColors = 4096
+
+// Canvas is a drawing buffer with 2x vertical resolution using half-block characters,
+// or 2x4 sub-pixels per cell using Braille patterns (see NewBrailleCanvas).
 // Supports scaling from logical coordinates to actual terminal pixels.
 // Uses double-buffering to only write cells that changed between frames,
 // eliminating the need for full-screen clearing and reducing SSH bandwidth.
 type Canvas struct {
-	termWidth      int    // Actual terminal columns
-	termHeight     int    // Actual terminal rows
-	subPixelHeight int    // termHeight * 2
-	pixels         []bool // Flat slice: [y * termWidth + x] - true if pixel is set
+	termWidth      int     // Actual terminal columns
+	termHeight     int     // Actual terminal rows
+	cellWidth      int     // Pixels per terminal column: 1, or 2 in Braille mode
+	cellHeight     int     // Pixels per terminal row: 2, or 4 in Braille mode
+	pixelWidth     int     // termWidth * cellWidth
+	subPixelHeight int     // termHeight * cellHeight
+	pixels         []bool  // Flat slice: [y * pixelWidth + x] - true if pixel is set
+	accent         []uint8 // Parallel to pixels: 1-based index into accents, 0 = no accent
 
 	// Scaling from logical to pixel coordinates
 	logicalWidth  float64 // Target/logical width
 	logicalHeight float64 // Target/logical height (in sub-pixels)
-	scaleX        float64 // termWidth / logicalWidth
-	scaleY        float64 // (termHeight*2) / logicalHeight
+	scaleX        float64 // pixelWidth / logicalWidth
+	scaleY        float64 // subPixelHeight / logicalHeight
 
 	// Offset for centering the render area when terminal is larger than max resolution.
 	// These are 0-based terminal offsets (columns/rows to skip).
@@ -73,9 +160,25 @@ type Canvas struct {
 	offsetRow int
 
 	// Double-buffering: track previous frame's cell states to render only diffs.
-	// prevCells packs state (low 2 bits) and dirty flag (bit 2) in one byte per cell.
-	prevCells   []byte // Packed: cellStateMask = state, cellDirtyBit = externally dirtied
-	forceRedraw bool   // Force all cells to be re-rendered next frame
+	// prevCells packs state (low 8 bits) and dirty flag (bit 8) per cell.
+	prevCells   []uint16 // Packed: cellStateMask = state, cellDirtyBit = externally dirtied
+	forceRedraw bool     // Force all cells to be re-rendered next frame
+
+	scanlines bool // CRT mode: render every other terminal row dimmed
+	dimmed    bool // Render every row dimmed (behind a menu overlay)
+	ascii     bool // Render cells with ASCII characters instead of half-blocks
+	braille   bool // Pack 2x4 sub-pixels per cell into Braille patterns
+
+	maxFrameBytes int // Cell output budget per Render (0 = unlimited); see SetMaxFrameBytes
+
+	accents    []string          // Accent colors (SGR sequences) used this frame
+	accentPen  uint8             // Accent index marked on pixels set now (0 = none); see SetAccent
+	prevColors []string          // Per cell: accent color it was last rendered in ("" = none)
+	rgbAccents map[uint32]uint8  // SetColored colors already in accents this frame (packed RGB -> index)
+	rgbColors  map[uint32]string // Sequences built by SetColored, kept across frames
+	colorLevel ColorLevel        // Color support SetColored renders for; see SetColorLevel
+
+	overlays []textOverlay // Text queued by DrawText, written at the end of Render
 
 	// Reusable buffers to reduce allocations
 	numBuf          [20]byte  // Scratch buffer for integer-to-string conversion
@@ -85,6 +188,12 @@ type Canvas struct {
 	borderHLine     string    // Cached horizontal border string (rebuilt on resize)
 }
 
+// textOverlay is a string queued for output on top of the rendered canvas.
+type textOverlay struct {
+	col, row int // 1-based terminal position within the canvas area
+	text     string
+}
+
 // NewCanvas creates a canvas for the given terminal dimensions.
 // The canvas has 2x vertical resolution (height*2 sub-pixels).
 // No scaling is applied (1:1 mapping).
@@ -95,42 +204,74 @@ func NewCanvas(width, height int) *Canvas {
 // NewScaledCanvas creates a canvas that scales from logical coordinates to terminal pixels.
 // logicalWidth/Height define the coordinate space used by game objects.
 // termWidth/Height are the actual terminal dimensions.
+//
+// Degenerate sizes don't break drawing: negative terminal sizes count as 0,
+// and a zero, negative or non-finite logical size maps 1:1 to pixels on that
+// axis (see scaleFactor).
 func NewScaledCanvas(termWidth, termHeight int, logicalWidth, logicalHeight float64) *Canvas {
-	subPixelHeight := termHeight * 2
-	totalCells := termWidth * termHeight
-	return &Canvas{
-		termWidth:      termWidth,
-		termHeight:     termHeight,
-		subPixelHeight: subPixelHeight,
-		pixels:         make([]bool, subPixelHeight*termWidth),
-		logicalWidth:   logicalWidth,
-		logicalHeight:  logicalHeight,
-		scaleX:         float64(termWidth) / logicalWidth,
-		scaleY:         float64(subPixelHeight) / logicalHeight,
-		prevCells:      make([]byte, totalCells),
-		forceRedraw:    true, // First frame must render everything
-		borderHLine:    strings.Repeat("─", termWidth),
+	c := &Canvas{
+		logicalWidth:  logicalWidth,
+		logicalHeight: logicalHeight,
 	}
+	c.allocate(max(termWidth, 0), max(termHeight, 0))
+	return c
+}
+
+// NewBrailleCanvas creates a scaled canvas like NewScaledCanvas that packs
+// 2x4 sub-pixels into each terminal cell using Braille patterns, for four
+// times the pixels of half-blocks. The font must have the U+2800 block.
+func NewBrailleCanvas(termWidth, termHeight int, logicalWidth, logicalHeight float64) *Canvas {
+	c := NewScaledCanvas(termWidth, termHeight, logicalWidth, logicalHeight)
+	c.SetBraille(true)
+	return c
 }
 
 // Resize updates the canvas for new terminal dimensions while keeping logical size.
 // Forces a full redraw on the next Render call when the size actually changes.
 func (c *Canvas) Resize(termWidth, termHeight int) {
+	termWidth, termHeight = max(termWidth, 0), max(termHeight, 0)
 	if termWidth == c.termWidth && termHeight == c.termHeight {
 		return
 	}
+	c.allocate(termWidth, termHeight)
+}
 
-	subPixelHeight := termHeight * 2
-	totalCells := termWidth * termHeight
-	c.pixels = make([]bool, subPixelHeight*termWidth)
-	c.prevCells = make([]byte, totalCells)
-	c.forceRedraw = true
+// allocate sizes the pixel and cell buffers for the given terminal
+// dimensions and the current cell mode, and forces a full redraw.
+func (c *Canvas) allocate(termWidth, termHeight int) {
+	c.cellWidth, c.cellHeight = 1, 2
+	if c.braille {
+		c.cellWidth, c.cellHeight = 2, 4
+	}
 	c.termWidth = termWidth
 	c.termHeight = termHeight
-	c.subPixelHeight = subPixelHeight
-	c.scaleX = float64(termWidth) / c.logicalWidth
-	c.scaleY = float64(subPixelHeight) / c.logicalHeight
-	c.borderHLine = strings.Repeat("─", termWidth)
+	c.pixelWidth = termWidth * c.cellWidth
+	c.subPixelHeight = termHeight * c.cellHeight
+
+	totalCells := termWidth * termHeight
+	c.pixels = make([]bool, c.subPixelHeight*c.pixelWidth)
+	c.accent = make([]uint8, c.subPixelHeight*c.pixelWidth)
+	c.prevColors = make([]string, totalCells)
+	c.prevCells = make([]uint16, totalCells)
+	c.forceRedraw = true // First frame after a reallocation must render everything
+	c.scaleX = scaleFactor(c.pixelWidth, c.logicalWidth)
+	c.scaleY = scaleFactor(c.subPixelHeight, c.logicalHeight)
+	c.borderHLine = strings.Repeat(c.Box().Horizontal, termWidth)
+}
+
+// minScale is the smallest scale factor a canvas uses. Below it, whole
+// worlds collapse onto a single pixel.
+const minScale = 1e-3
+
+// scaleFactor returns the pixels per logical unit for an axis of the given
+// pixel and logical sizes. An unusable logical size (zero, negative, NaN or
+// infinite) maps 1:1, and tiny factors are raised to minScale, so drawing
+// never divides by zero or produces NaN coordinates.
+func scaleFactor(pixels int, logical float64) float64 {
+	if !(logical > 0) || math.IsInf(logical, 0) {
+		return 1
+	}
+	return max(float64(pixels)/logical, minScale)
 }
 
 // SetOffset sets the column and row offset for centering the canvas.
@@ -140,6 +281,164 @@ func (c *Canvas) SetOffset(col, row int) {
 	c.offsetRow = row
 }
 
+// SetScanlines enables or disables the CRT scanline effect, which renders
+// every other terminal row dimmed. Forces a full redraw when the setting
+// changes, since the dim state is part of every cell's rendered output.
+func (c *Canvas) SetScanlines(enabled bool) {
+	if c.scanlines != enabled {
+		c.scanlines = enabled
+		c.forceRedraw = true
+	}
+}
+
+// SetDimmed renders the whole canvas dimmed, to push the game into the
+// background behind an overlay. Forces a full redraw when it changes.
+func (c *Canvas) SetDimmed(enabled bool) {
+	if c.dimmed != enabled {
+		c.dimmed = enabled
+		c.forceRedraw = true
+	}
+}
+
+// SetAccent draws the pixels set from now on in color (an SGR foreground
+// sequence) until SetAccent("") or the next Clear. A cell is rendered in the
+// accent of its top pixel, else of its bottom pixel. A cell whose color
+// changes is redrawn even where its shape did not. Up to maxAccents colors
+// can be used per frame; further ones draw without an accent.
+func (c *Canvas) SetAccent(color string) {
+	c.accentPen = 0
+	if color == "" {
+		return
+	}
+	for i, a := range c.accents {
+		if a == color {
+			c.accentPen = uint8(i + 1)
+			return
+		}
+	}
+	if len(c.accents) < maxAccents {
+		c.accents = append(c.accents, color)
+		c.accentPen = uint8(len(c.accents))
+	}
+}
+
+// accentColor returns the color of a 1-based accent index ("" for 0).
+func (c *Canvas) accentColor(idx uint8) string {
+	if idx == 0 {
+		return ""
+	}
+	return c.accents[idx-1]
+}
+
+// halfBlockCell returns the state of a half-block cell and the accent of its
+// top pixel, else of its bottom pixel.
+func (c *Canvas) halfBlockCell(col, row int) (cellState, uint8) {
+	topIdx := row*2*c.pixelWidth + col
+	bottomIdx := topIdx + c.pixelWidth
+	top := c.pixels[topIdx]
+	bottom := row*2+1 < c.subPixelHeight && c.pixels[bottomIdx]
+
+	var accent uint8
+	if top {
+		accent = c.accent[topIdx]
+	}
+	if accent == 0 && bottom {
+		accent = c.accent[bottomIdx]
+	}
+
+	switch {
+	case top && bottom:
+		return cellFull, accent
+	case top:
+		return cellUpper, accent
+	case bottom:
+		return cellLower, accent
+	}
+	return cellEmpty, 0
+}
+
+// brailleCell returns the dot pattern of a Braille cell and the accent of
+// its first colored pixel, scanning rows top to bottom.
+func (c *Canvas) brailleCell(col, row int) (cellState, uint8) {
+	var pattern cellState
+	var accent uint8
+	for dy := range brailleDots {
+		base := (row*4+dy)*c.pixelWidth + col*2
+		for dx, dot := range brailleDots[dy] {
+			if c.pixels[base+dx] {
+				pattern |= dot
+				if accent == 0 {
+					accent = c.accent[base+dx]
+				}
+			}
+		}
+	}
+	return pattern, accent
+}
+
+// Scanlines reports whether the CRT scanline effect is enabled.
+func (c *Canvas) Scanlines() bool {
+	return c.scanlines
+}
+
+// SetASCII selects ASCII cell characters (see ASCIIFull and friends) instead of
+// half-blocks, and an ASCII border (see Box), for terminals, fonts or
+// non-UTF-8 locales that render them as boxes, mojibake or not at all.
+// Forces a full redraw when the setting changes.
+func (c *Canvas) SetASCII(enabled bool) {
+	if c.ascii != enabled {
+		c.ascii = enabled
+		c.forceRedraw = true
+		c.borderHLine = strings.Repeat(c.Box().Horizontal, c.termWidth)
+	}
+}
+
+// SetBraille switches between half-block cells (2 sub-pixels each) and
+// Braille cells (2x4 sub-pixels each). The logical size is kept, so drawing
+// code is unaffected; the pixel buffers are reallocated and the next Render
+// redraws everything. ASCII mode takes precedence when drawing the cells.
+func (c *Canvas) SetBraille(enabled bool) {
+	if c.braille != enabled {
+		c.braille = enabled
+		c.allocate(c.termWidth, c.termHeight)
+	}
+}
+
+// Braille reports whether the canvas packs 2x4 sub-pixels per cell.
+func (c *Canvas) Braille() bool {
+	return c.braille
+}
+
+// Box returns the frame characters matching the ASCII setting, for the
+// border and for UI frames drawn around the canvas.
+func (c *Canvas) Box() Box {
+	if c.ascii {
+		return BoxASCII
+	}
+	return BoxLight
+}
+
+// ASCII reports whether ASCII cell characters are in use.
+func (c *Canvas) ASCII() bool {
+	return c.ascii
+}
+
+// SetMaxFrameBytes caps the bytes of cell output a single Render may write
+// (0, the default, disables the cap). On slow links this spreads a large
+// redraw (resize, screen clear) over several frames instead of stalling one
+// write, so input keeps being processed in between.
+//
+// Correctness relies on prevCells only ever describing what the terminal
+// actually shows: cells skipped for lack of budget keep their previous state
+// and dirty bit, so the next Render still sees them as changed, and a forced
+// redraw is turned into dirty bits on every cell rather than a one-shot flag
+// that a partial Render would lose. Until the backlog drains, parts of the
+// screen lag a frame or more behind; the budget only limits cell output, not
+// text overlays or UI drawn after Render.
+func (c *Canvas) SetMaxFrameBytes(n int) {
+	c.maxFrameBytes = max(n, 0)
+}
+
 // OffsetCol returns the column offset used for centering.
 func (c *Canvas) OffsetCol() int {
 	return c.offsetCol
@@ -150,15 +449,54 @@ func (c *Canvas) OffsetRow() int {
 	return c.offsetRow
 }
 
-// Clear resets all pixels in the canvas.
+// Clear resets all pixels in the canvas and drops any queued text.
 func (c *Canvas) Clear() {
 	clear(c.pixels)
+	clear(c.accent)
+	c.accents = c.accents[:0]
+	c.accentPen = 0
+	clear(c.rgbAccents)
+	c.overlays = c.overlays[:0]
+}
+
+// DrawText queues text to be written on top of the canvas at a 1-based
+// terminal position within the canvas area (matching MarkTextDirty).
+// Queued text is written at the end of the next Render, after the cells, and
+// its cells are marked dirty so the following frame restores the canvas
+// underneath. Text is clipped to the canvas; each rune is assumed to occupy
+// one column.
+func (c *Canvas) DrawText(col, row int, s string) {
+	if s == "" || row < 1 || row > c.termHeight || col > c.termWidth {
+		return
+	}
+	// Clip on the left
+	for col < 1 && s != "" {
+		_, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		col++
+	}
+	// Clip on the right
+	n := 0
+	for i := range s {
+		if col+n > c.termWidth {
+			s = s[:i]
+			break
+		}
+		n++
+	}
+	if s == "" {
+		return
+	}
+	c.overlays = append(c.overlays, textOverlay{col: col, row: row, text: s})
 }
 
 // setPixel sets a pixel at actual terminal coordinates (no scaling).
 func (c *Canvas) setPixel(x, y int) {
-	if x >= 0 && x < c.termWidth && y >= 0 && y < c.subPixelHeight {
-		c.pixels[y*c.termWidth+x] = true
+	if x >= 0 && x < c.pixelWidth && y >= 0 && y < c.subPixelHeight {
+		c.pixels[y*c.pixelWidth+x] = true
+		if c.accentPen != 0 {
+			c.accent[y*c.pixelWidth+x] = c.accentPen
+		}
 	}
 }
 
@@ -169,6 +507,86 @@ func (c *Canvas) Set(x, y int) {
 	c.setPixel(px, py)
 }
 
+// SetColored sets a pixel at logical coordinates in a 24-bit color, leaving
+// the accent pen unchanged. The color is sent exactly on truecolor terminals
+// and as the nearest palette entry on 256-color ones (see SetColorLevel);
+// with fewer colors the pixel is set in the current accent. Colors share the
+// per-frame accent palette, so past maxAccents distinct colors a frame the
+// pixel is set uncolored.
+func (c *Canvas) SetColored(x, y float64, r, g, b uint8) {
+	key := uint32(r)<<16 | uint32(g)<<8 | uint32(b)
+	idx, ok := c.rgbAccents[key]
+	if !ok {
+		color, cached := c.rgbColors[key]
+		if !cached {
+			if c.rgbColors == nil || len(c.rgbColors) >= maxRGBColors {
+				c.rgbColors = make(map[uint32]string)
+			}
+			color = c.rgbSequence(r, g, b)
+			c.rgbColors[key] = color
+		}
+		if color == "" {
+			c.SetFloat(x, y)
+			return
+		}
+		pen := c.accentPen
+		c.SetAccent(color)
+		idx = c.accentPen
+		c.accentPen = pen
+		if idx != 0 {
+			if c.rgbAccents == nil {
+				c.rgbAccents = make(map[uint32]uint8)
+			}
+			c.rgbAccents[key] = idx
+		}
+	}
+
+	pen := c.accentPen
+	c.accentPen = idx
+	c.SetFloat(x, y)
+	c.accentPen = pen
+}
+
+// SetColorLevel sets the terminal color support SetColored renders for.
+// The zero value, ColorLevelNone, draws SetColored pixels uncolored.
+func (c *Canvas) SetColorLevel(level ColorLevel) {
+	if level == c.colorLevel {
+		return
+	}
+	c.colorLevel = level
+	c.rgbColors = nil
+	clear(c.rgbAccents)
+}
+
+// rgbSequence returns the SGR sequence for an RGB color at the canvas's
+// color level, or "" below 256 colors.
+func (c *Canvas) rgbSequence(r, g, b uint8) string {
+	switch {
+	case c.colorLevel >= ColorLevelTrue:
+		return TrueColor(r, g, b)
+	case c.colorLevel == ColorLevel256:
+		return Color256(rgbTo256(r, g, b))
+	default:
+		return ""
+	}
+}
+
+// rgbTo256 returns the xterm 256-color palette index of the 6x6x6 color
+// cube entry nearest to an RGB color.
+func rgbTo256(r, g, b uint8) uint8 {
+	level := func(v uint8) uint8 {
+		// Cube levels are 0, 95, 135, 175, 215 and 255
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	return 16 + 36*level(r) + 6*level(g) + level(b)
+}
+
 // SetFloat sets a pixel using float logical coordinates (applies scaling).
 func (c *Canvas) SetFloat(x, y float64) {
 	px := int(math.Round(x * c.scaleX))
@@ -177,13 +595,24 @@ func (c *Canvas) SetFloat(x, y float64) {
 }
 
 // DrawLine draws a line on the canvas using Bresenham's algorithm.
-// Coordinates are in logical space and get scaled to pixels.
+// Coordinates are in logical space and get scaled to pixels. Lines with
+// non-finite endpoints are skipped, and lines are clipped to the canvas
+// before rasterizing, so bogus coordinates can't stall a frame.
 func (c *Canvas) DrawLine(p1, p2 Point) {
 	// Scale to pixel coordinates for drawing
-	x1 := int(math.Round(p1.X * c.scaleX))
-	y1 := int(math.Round(p1.Y * c.scaleY))
-	x2 := int(math.Round(p2.X * c.scaleX))
-	y2 := int(math.Round(p2.Y * c.scaleY))
+	a := Point{X: p1.X * c.scaleX, Y: p1.Y * c.scaleY}
+	b := Point{X: p2.X * c.scaleX, Y: p2.Y * c.scaleY}
+	if !finite(a) || !finite(b) {
+		return
+	}
+	a, b, ok := clipLine(a, b, float64(c.pixelWidth-1), float64(c.subPixelHeight-1))
+	if !ok {
+		return
+	}
+	x1 := int(math.Round(a.X))
+	y1 := int(math.Round(a.Y))
+	x2 := int(math.Round(b.X))
+	y2 := int(math.Round(b.Y))
 
 	dx := abs(x2 - x1)
 	dy := abs(y2 - y1)
@@ -218,6 +647,42 @@ func (c *Canvas) DrawLine(p1, p2 Point) {
 	}
 }
 
+// clipLine clips the segment a-b to the rectangle from (0, 0) to
+// (maxX, maxY) (Liang-Barsky). ok is false when no part of it lies inside.
+func clipLine(a, b Point, maxX, maxY float64) (Point, Point, bool) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if !finite(Point{X: dx, Y: dy}) {
+		return a, b, false
+	}
+	t0, t1 := 0.0, 1.0
+	// Each edge as (p, q): the segment is inside where t*p <= q
+	edges := [4][2]float64{{-dx, a.X}, {dx, maxX - a.X}, {-dy, a.Y}, {dy, maxY - a.Y}}
+	for _, e := range edges {
+		p, q := e[0], e[1]
+		if p == 0 {
+			if q < 0 {
+				return a, b, false // Parallel to and outside this edge
+			}
+			continue
+		}
+		r := q / p
+		if p < 0 {
+			t0 = max(t0, r)
+		} else {
+			t1 = min(t1, r)
+		}
+		if t0 > t1 {
+			return a, b, false
+		}
+	}
+	return Point{X: a.X + t0*dx, Y: a.Y + t0*dy}, Point{X: a.X + t1*dx, Y: a.Y + t1*dy}, true
+}
+
+// finite reports whether both of p's coordinates are finite numbers.
+func finite(p Point) bool {
+	return !math.IsNaN(p.X) && !math.IsInf(p.X, 0) && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0)
+}
+
 // DrawPolygon draws a polygon on the canvas.
 // If filled is true, the interior is filled using scanline algorithm.
 func (c *Canvas) DrawPolygon(points []Point, filled bool) {
@@ -251,6 +716,9 @@ func (c *Canvas) fillPolygon(points []Point) {
 			X: p.X * c.scaleX,
 			Y: p.Y * c.scaleY,
 		}
+		if !finite(scaled[i]) {
+			return
+		}
 	}
 
 	// Find bounding box in pixel space
@@ -264,8 +732,15 @@ func (c *Canvas) fillPolygon(points []Point) {
 		}
 	}
 
+	// Only scanlines on the canvas are filled
+	minY = max(minY, 0)
+	maxY = min(maxY, float64(c.subPixelHeight-1))
+	if minY > maxY {
+		return
+	}
 	yStart := int(math.Floor(minY))
 	yEnd := int(math.Ceil(maxY))
+	maxX := float64(c.pixelWidth - 1)
 
 	// Scanline fill in pixel space
 	for y := yStart; y <= yEnd; y++ {
@@ -293,8 +768,14 @@ func (c *Canvas) fillPolygon(points []Point) {
 		slices.Sort(intersections)
 
 		for i := 0; i+1 < len(intersections); i += 2 {
-			xStart := int(math.Ceil(intersections[i]))
-			xEnd := int(math.Floor(intersections[i+1]))
+			// Clamp each span to the canvas; huge coordinates can't be
+			// converted to int safely
+			left, right := max(intersections[i], 0), min(intersections[i+1], maxX)
+			if math.IsNaN(left) || math.IsNaN(right) || left > right {
+				continue
+			}
+			xStart := int(math.Ceil(left))
+			xEnd := int(math.Floor(right))
 			for x := xStart; x <= xEnd; x++ {
 				c.setPixel(x, y)
 			}
@@ -302,11 +783,7 @@ func (c *Canvas) fillPolygon(points []Point) {
 	}
 }
 
-// maxChunkSize is the maximum bytes to write at once for optimal network flow.
-// 1500 bytes matches typical MTU size for smooth SSH/network transmission.
-const maxChunkSize = 1400
-
-// Render outputs the canvas to the chunk writer using half-block characters.
+// Render outputs the canvas to the chunk writer using half-block (or Braille) characters.
 // Uses double-buffering: only cells that changed since the previous frame
 // (or were externally dirtied via MarkTextDirty) are written. Empty cells
 // that were previously filled are overwritten with spaces, eliminating
@@ -315,44 +792,63 @@ const maxChunkSize = 1400
 // When consecutive changed cells appear in a row, only the first emits a
 // CSI cursor-position sequence; subsequent cells rely on the terminal's
 // auto-advancing cursor, saving ~10 bytes per sequential cell.
+//
+// With scanlines enabled, odd rows are wrapped in a dim/normal-intensity pair.
+// Whether a row is dimmed depends only on its index, so unchanged cells keep
+// their correct appearance and diffing is unaffected; toggling the effect
+// forces a full redraw (see SetScanlines). SetDimmed dims every row the same way.
+// Every color or intensity change is undone before the row or cell ends, so
+// Render never leaves an SGR attribute open.
 func (c *Canvas) Render(cw *ChunkWriter) {
 	force := c.forceRedraw
 	c.forceRedraw = false
 
+	// With a byte budget, a forced redraw may not finish in one frame; express
+	// it as dirty bits so skipped cells are picked up by the next Render.
+	budget := c.maxFrameBytes
+	if force && budget > 0 {
+		for i := range c.prevCells {
+			c.prevCells[i] |= cellDirtyBit
+		}
+		force = false
+	}
+	budgetStart := cw.Len()
+
 	for row := 0; row < c.termHeight; row++ {
-		topY := row * 2
-		bottomY := row*2 + 1
-		topOffset := topY * c.termWidth
-		bottomOffset := bottomY * c.termWidth
 		rowBase := row * c.termWidth
 		lastWrittenCol := -2 // Track last column written for run detection
+		dimRow := c.dimmed || (c.scanlines && row%2 == 1)
 
 		for col := 0; col < c.termWidth; col++ {
-			top := c.pixels[topOffset+col]
-			bottom := bottomY < c.subPixelHeight && c.pixels[bottomOffset+col]
-
 			var current cellState
-			switch {
-			case top && bottom:
-				current = cellFull
-			case top:
-				current = cellUpper
-			case bottom:
-				current = cellLower
-			default:
-				current = cellEmpty
+			var accent uint8
+			if c.braille {
+				current, accent = c.brailleCell(col, row)
+			} else {
+				current, accent = c.halfBlockCell(col, row)
 			}
+			color := c.accentColor(accent)
 
 			cellIdx := rowBase + col
 			packed := c.prevCells[cellIdx]
 			prev := cellState(packed & cellStateMask)
 			dirty := packed&cellDirtyBit != 0
-			c.prevCells[cellIdx] = byte(current)
 
-			if !force && !dirty && current == prev {
+			if !force && !dirty && current == prev && color == c.prevColors[cellIdx] {
 				continue
 			}
 
+			// Over budget: leave prevCells untouched so the cell is retried next frame
+			if budget > 0 && cw.Len()-budgetStart >= budget {
+				continue
+			}
+			c.prevCells[cellIdx] = uint16(current)
+			c.prevColors[cellIdx] = color
+
+			if dimRow && lastWrittenCol < 0 {
+				cw.WriteString(ColorDim)
+			}
+
 			// Only emit CSI when cursor isn't already at the right position
 			if col != lastWrittenCol+1 {
 				cw.WriteString("\033[")
@@ -363,18 +859,42 @@ func (c *Canvas) Render(cw *ChunkWriter) {
 			}
 			lastWrittenCol = col
 
-			switch current {
-			case cellFull:
+			if color != "" {
+				cw.WriteString(color)
+			}
+			switch {
+			case current == cellEmpty:
+				cw.WriteByte(' ')
+			case c.braille && c.ascii:
+				cw.WriteByte(asciiCells[brailleHalves(current)])
+			case c.braille:
+				cw.WriteRune(BrailleBlank + rune(current))
+			case c.ascii:
+				cw.WriteByte(asciiCells[current])
+			case current == cellFull:
 				cw.WriteRune(BlockFull)
-			case cellUpper:
+			case current == cellUpper:
 				cw.WriteRune(BlockUpperHalf)
-			case cellLower:
+			case current == cellLower:
 				cw.WriteRune(BlockLowerHalf)
-			case cellEmpty:
-				cw.WriteByte(' ')
 			}
+			if color != "" {
+				cw.WriteString(ColorDefault)
+			}
+		}
+
+		if dimRow && lastWrittenCol >= 0 {
+			cw.WriteString(ColorNormal)
 		}
 	}
+
+	// Text queued via DrawText goes on top; dirty it so next frame cleans it up
+	for _, o := range c.overlays {
+		c.writeCSI(cw, o.row+c.offsetRow, o.col+c.offsetCol)
+		cw.WriteString(o.text)
+		c.MarkTextDirty(o.col, o.row, utf8.RuneCountInString(o.text))
+	}
+	c.overlays = c.overlays[:0]
 }
 
 // RenderBorder draws a box border around the canvas area when the terminal
@@ -392,15 +912,16 @@ func (c *Canvas) RenderBorder(cw *ChunkWriter) {
 	bottom := c.offsetRow + c.termHeight + 1
 
 	hLine := c.borderHLine
+	box := c.Box()
 
 	if hasV {
 		// Top border
 		if hasH {
 			// Full top: ┌───┐
 			c.writeCSI(cw, top, left)
-			cw.WriteString("┌")
+			cw.WriteString(box.TopLeft)
 			cw.WriteString(hLine)
-			cw.WriteString("┐")
+			cw.WriteString(box.TopRight)
 		} else {
 			// Top without corners: ───
 			c.writeCSI(cw, top, c.offsetCol+1)
@@ -411,9 +932,9 @@ func (c *Canvas) RenderBorder(cw *ChunkWriter) {
 		if hasH {
 			// Full bottom: └───┘
 			c.writeCSI(cw, bottom, left)
-			cw.WriteString("└")
+			cw.WriteString(box.BottomLeft)
 			cw.WriteString(hLine)
-			cw.WriteString("┘")
+			cw.WriteString(box.BottomRight)
 		} else {
 			// Bottom without corners: ───
 			c.writeCSI(cw, bottom, c.offsetCol+1)
@@ -432,9 +953,9 @@ func (c *Canvas) RenderBorder(cw *ChunkWriter) {
 		}
 		for row := startRow; row < endRow; row++ {
 			c.writeCSI(cw, row, left)
-			cw.WriteString("│")
+			cw.WriteString(box.Vertical)
 			c.writeCSI(cw, row, right)
-			cw.WriteString("│")
+			cw.WriteString(box.Vertical)
 		}
 	}
 }
@@ -464,13 +985,15 @@ func (c *Canvas) TerminalHeight() int {
 func (c *Canvas) LogicalToTerminal(x, y float64) (col, row int) {
 	px := int(math.Round(x * c.scaleX))
 	py := int(math.Round(y * c.scaleY))
-	return px + 1, py/2 + 1
+	return px/c.cellWidth + 1, py/c.cellHeight + 1
 }
 
 // ForceRedraw marks the canvas so the next Render call writes every cell,
-// regardless of whether it changed. Use after a full terminal clear or resize.
+// regardless of whether it changed, and forgets the colors cells were last
+// drawn in. Use after a full terminal clear or resize.
 func (c *Canvas) ForceRedraw() {
 	c.forceRedraw = true
+	clear(c.prevColors)
 }
 
 // MarkTextDirty marks terminal cells as externally modified (e.g. by UI text overlays).
@@ -0,0 +1,168 @@
+package draw
+
+import (
+	"io"
+	"math"
+	"testing"
+	"time"
+)
+
+// setPixels returns the number of pixels set on c.
+func setPixels(c *Canvas) int {
+	n := 0
+	for _, on := range c.pixels {
+		if on {
+			n++
+		}
+	}
+	return n
+}
+
+func TestScaleFactorDegenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pixels  int
+		logical float64
+		want    float64
+	}{
+		{"normal", 100, 50, 2},
+		{"zero logical", 100, 0, 1},
+		{"negative logical", 100, -5, 1},
+		{"NaN logical", 100, math.NaN(), 1},
+		{"infinite logical", 100, math.Inf(1), 1},
+		{"zero pixels", 0, 100, minScale},
+		{"tiny ratio", 1, 1e9, minScale},
+	}
+	for _, tt := range tests {
+		if got := scaleFactor(tt.pixels, tt.logical); got != tt.want {
+			t.Errorf("%s: scaleFactor(%d, %v) = %v, want %v", tt.name, tt.pixels, tt.logical, got, tt.want)
+		}
+	}
+}
+
+func TestDegenerateCanvasSizesDrawSafely(t *testing.T) {
+	sizes := []struct {
+		termW, termH       int
+		logicalW, logicalH float64
+	}{
+		{-5, -5, 10, 10},
+		{0, 0, 0, 0},
+		{10, 5, 0, -1},
+		{10, 5, math.NaN(), math.Inf(1)},
+		{10, 5, 1e-12, 1e-12},
+		{10, 5, 1e12, 1e12},
+	}
+	for _, s := range sizes {
+		c := NewScaledCanvas(s.termW, s.termH, s.logicalW, s.logicalH)
+		for _, scale := range []float64{c.scaleX, c.scaleY} {
+			if !(scale >= minScale) || math.IsInf(scale, 0) {
+				t.Errorf("%+v: scale %v, want finite and >= %v", s, scale, minScale)
+			}
+		}
+		c.Resize(-1, -1)
+		c.Resize(s.termW, s.termH)
+		c.Set(3, 3)
+		c.DrawLine(Point{X: 0, Y: 0}, Point{X: 9, Y: 4})
+		c.DrawPolygon([]Point{{X: 1, Y: 1}, {X: 8, Y: 1}, {X: 5, Y: 4}}, true)
+		c.Render(NewChunkWriter(io.Discard, 0, 0))
+	}
+}
+
+func TestDrawLineRejectsNonFinite(t *testing.T) {
+	c := NewScaledCanvas(20, 10, 20, 20)
+	bad := []float64{math.NaN(), math.Inf(1), math.Inf(-1)}
+	for _, v := range bad {
+		c.DrawLine(Point{X: v, Y: 5}, Point{X: 10, Y: 5})
+		c.DrawLine(Point{X: 5, Y: 5}, Point{X: 10, Y: v})
+	}
+	if n := setPixels(c); n != 0 {
+		t.Errorf("non-finite lines set %d pixels, want 0", n)
+	}
+}
+
+func TestDrawLineClipsHugeCoordinates(t *testing.T) {
+	c := NewScaledCanvas(20, 10, 20, 20)
+	start := time.Now()
+	c.DrawLine(Point{X: -1e15, Y: 5}, Point{X: 1e15, Y: 5})
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("huge line took %v", d)
+	}
+	// The visible part spans the whole row
+	y := int(math.Round(5 * c.scaleY))
+	for x := 0; x < c.pixelWidth; x++ {
+		if !c.pixels[y*c.pixelWidth+x] {
+			t.Fatalf("pixel (%d, %d) not set by a line crossing the canvas", x, y)
+		}
+	}
+	if n := setPixels(c); n != c.pixelWidth {
+		t.Errorf("set %d pixels, want one row of %d", n, c.pixelWidth)
+	}
+
+	// Entirely off the canvas
+	c.Clear()
+	c.DrawLine(Point{X: -1e15, Y: -1e15}, Point{X: -1e14, Y: 1e15})
+	if n := setPixels(c); n != 0 {
+		t.Errorf("off-canvas line set %d pixels, want 0", n)
+	}
+}
+
+func TestFillPolygonHugeAndNonFinite(t *testing.T) {
+	c := NewScaledCanvas(20, 10, 20, 20)
+	start := time.Now()
+	c.DrawPolygon([]Point{{X: -1e15, Y: -1e15}, {X: 1e15, Y: -1e15}, {X: 1e15, Y: 1e15}, {X: -1e15, Y: 1e15}}, true)
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("huge polygon took %v", d)
+	}
+	if n, want := setPixels(c), len(c.pixels); n != want {
+		t.Errorf("polygon covering the canvas set %d pixels, want all %d", n, want)
+	}
+
+	c.Clear()
+	c.DrawPolygon([]Point{{X: 1, Y: 1}, {X: math.Inf(1), Y: 1}, {X: 5, Y: math.NaN()}}, true)
+	if n := setPixels(c); n != 0 {
+		t.Errorf("non-finite polygon set %d pixels, want 0", n)
+	}
+}
+
+func TestSetColoredFollowsColorLevel(t *testing.T) {
+	tests := []struct {
+		level ColorLevel
+		want  string // Accent color of the pixel
+	}{
+		{ColorLevelNone, ""},
+		{ColorLevel16, ""},
+		{ColorLevel256, Color256(rgbTo256(255, 128, 0))},
+		{ColorLevelTrue, TrueColor(255, 128, 0)},
+	}
+	for _, tt := range tests {
+		c := NewScaledCanvas(20, 10, 20, 20)
+		c.SetColorLevel(tt.level)
+		c.SetColored(5, 5, 255, 128, 0)
+		i := 5*c.pixelWidth + 5
+		if !c.pixels[i] {
+			t.Errorf("level %d: pixel not set", tt.level)
+			continue
+		}
+		if got := c.accentColor(c.accent[i]); got != tt.want {
+			t.Errorf("level %d: pixel color %q, want %q", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestRGBTo256(t *testing.T) {
+	tests := []struct {
+		r, g, b uint8
+		want    uint8
+	}{
+		{0, 0, 0, 16},
+		{255, 255, 255, 231},
+		{255, 0, 0, 196},
+		{95, 135, 175, 16 + 36*1 + 6*2 + 3},
+		{114, 116, 0, 16 + 36*1 + 6*2},
+	}
+	for _, tt := range tests {
+		if got := rgbTo256(tt.r, tt.g, tt.b); got != tt.want {
+			t.Errorf("rgbTo256(%d, %d, %d) = %d, want %d", tt.r, tt.g, tt.b, got, tt.want)
+		}
+	}
+}
@@ -19,6 +19,32 @@ const (
 	BlockRightHalf = '▐'
 )
 
+// BrailleBlank is the empty Braille pattern (U+2800). Adding a dot bitmask
+// gives the pattern with those dots raised.
+const BrailleBlank = '\u2800'
+
+// ASCII fallback characters for terminals or fonts without half-block glyphs.
+// The top and bottom sub-pixels of a cell can still be told apart, but the
+// result is coarser than with half-blocks.
+const (
+	ASCIIFull      = '#'
+	ASCIIUpperHalf = '\''
+	ASCIILowerHalf = '.'
+)
+
+// Box holds the characters that draw a rectangular frame.
+type Box struct {
+	Horizontal, Vertical                       string
+	TopLeft, TopRight, BottomLeft, BottomRight string
+}
+
+// Frames drawn with light box-drawing lines, and the ASCII fallback used
+// alongside the ASCII cell characters.
+var (
+	BoxLight = Box{"─", "│", "┌", "┐", "└", "┘"}
+	BoxASCII = Box{"-", "|", "+", "+", "+", "+"}
+)
+
 // Shades are characters from lightest to darkest.
 // Use these to render different intensities in the terminal.
 var Shades = []rune{' ', '░', '▒', '▓', '█'}
@@ -1,10 +1,14 @@
 package draw
 
 import (
-	"bufio"
+	"errors"
 	"io"
+	"net"
 	"os"
 	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"unicode/utf8"
 
 	"golang.org/x/term"
@@ -14,24 +18,43 @@ import (
 // network flow (e.g. over SSH). Use MoveCursor, WriteString, WriteRune to accumulate,
 // then Flush to write to the underlying writer. Implements io.Writer for Canvas.Render.
 //
-// Uses a reusable []byte buffer instead of strings.Builder so the backing array
-// survives across frames (buf[:0] retains capacity, avoiding re-growth allocations).
+// The frame buffer is borrowed from a shared pool on the first write of a frame
+// and returned on Flush, so idle clients hold no buffer memory and the total
+// scales with the number of clients rendering at the same moment rather than
+// the number connected. Frames larger than maxFrameBufSize are written out
+// mid-render in several chunks instead of growing the buffer without bound.
 type ChunkWriter struct {
-	buf    []byte
-	bufw   *bufio.Writer // Buffers writes to underlying writer for fewer syscalls
-	numBuf [20]byte      // Scratch buffer for allocation-free integer formatting
+	buf    []byte    // Borrowed from frameBufPool while a frame is being built; nil otherwise
+	w      io.Writer // Underlying writer
+	err    error     // First error from a mid-render write, reported by Flush
+	sent   int       // Bytes of the current frame already written out mid-render
 	offCol int
 	offRow int
 }
 
+const (
+	// initialFrameBufSize is the capacity of newly allocated pooled frame buffers.
+	initialFrameBufSize = 32768
+
+	// maxFrameBufSize is the buffered byte count at which a frame is written out
+	// mid-render. Typical diff frames stay far below it; only full redraws of
+	// large terminals cross it.
+	maxFrameBufSize = 65536
+)
+
+// frameBufPool shares frame buffers across all ChunkWriters.
+var frameBufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, initialFrameBufSize)
+		return &b
+	},
+}
+
 // NewChunkWriter creates a ChunkWriter that writes to w. offsetCol and offsetRow
 // are added to all MoveCursor coordinates (for canvas centering).
-// Uses a 64KB bufio buffer so most frames flush in a single ptyWriter.Write call,
-// reducing the per-write bytes.Replace overhead in the SSH transport layer.
 func NewChunkWriter(w io.Writer, offsetCol, offsetRow int) *ChunkWriter {
 	return &ChunkWriter{
-		buf:    make([]byte, 0, 32768),
-		bufw:   bufio.NewWriterSize(w, 65536),
+		w:      w,
 		offCol: offsetCol,
 		offRow: offsetRow,
 	}
@@ -43,9 +66,33 @@ func (cw *ChunkWriter) SetOffset(offsetCol, offsetRow int) {
 	cw.offRow = offsetRow
 }
 
+// acquire borrows a frame buffer from the pool if none is held, and writes the
+// buffered bytes out early when the frame has grown past maxFrameBufSize.
+// Called before every append.
+func (cw *ChunkWriter) acquire() {
+	if cw.buf == nil {
+		cw.buf = (*frameBufPool.Get().(*[]byte))[:0]
+		return
+	}
+	if len(cw.buf) >= maxFrameBufSize {
+		cw.writeOut()
+	}
+}
+
+// writeOut writes the buffered bytes to the underlying writer and resets the
+// buffer length. After the first error, further output is discarded.
+func (cw *ChunkWriter) writeOut() {
+	if len(cw.buf) > 0 && cw.err == nil {
+		_, cw.err = cw.w.Write(cw.buf)
+	}
+	cw.sent += len(cw.buf)
+	cw.buf = cw.buf[:0]
+}
+
 // MoveCursor appends an ANSI cursor position sequence. col and row are 1-based
 // canvas coordinates; offset is applied automatically.
 func (cw *ChunkWriter) MoveCursor(col, row int) {
+	cw.acquire()
 	cw.buf = append(cw.buf, "\033["...)
 	cw.buf = strconv.AppendInt(cw.buf, int64(row+cw.offRow), 10)
 	cw.buf = append(cw.buf, ';')
@@ -55,12 +102,14 @@ func (cw *ChunkWriter) MoveCursor(col, row int) {
 
 // Write implements io.Writer for use with Canvas.Render and other writers.
 func (cw *ChunkWriter) Write(p []byte) (n int, err error) {
+	cw.acquire()
 	cw.buf = append(cw.buf, p...)
 	return len(p), nil
 }
 
 // WriteString appends a string to the buffer.
 func (cw *ChunkWriter) WriteString(s string) {
+	cw.acquire()
 	cw.buf = append(cw.buf, s...)
 }
 
@@ -70,36 +119,215 @@ func (cw *ChunkWriter) WriteAt(col, row int, s string) {
 	cw.buf = append(cw.buf, s...)
 }
 
+// WriteColoredAt writes s at a specific position wrapped in the given SGR color
+// sequence and a trailing reset. An empty color writes s uncolored.
+func (cw *ChunkWriter) WriteColoredAt(col, row int, color, s string) {
+	cw.MoveCursor(col, row)
+	if color == "" {
+		cw.buf = append(cw.buf, s...)
+		return
+	}
+	cw.buf = append(cw.buf, color...)
+	cw.buf = append(cw.buf, s...)
+	cw.buf = append(cw.buf, ColorReset...)
+}
+
 // WriteByte appends a byte to the buffer.
 func (cw *ChunkWriter) WriteByte(c byte) error {
+	cw.acquire()
 	cw.buf = append(cw.buf, c)
 	return nil
 }
 
 // WriteRune appends a rune to the buffer.
 func (cw *ChunkWriter) WriteRune(r rune) {
+	cw.acquire()
 	cw.buf = utf8.AppendRune(cw.buf, r)
 }
 
+// Len returns the number of bytes in the current frame so far, including any
+// already written out mid-render.
+func (cw *ChunkWriter) Len() int {
+	return cw.sent + len(cw.buf)
+}
+
 // Ensure ChunkWriter satisfies io.Writer.
 var _ io.Writer = (*ChunkWriter)(nil)
 
-// Flush writes the accumulated buffer to the underlying writer in chunks,
-// then resets the buffer. Retains the backing array for reuse next frame.
+// Flush ends the frame with an SGR reset, writes the remainder to the
+// underlying writer and returns the frame buffer to the shared pool. The reset
+// means a color left open anywhere in the frame can't bleed into the next
+// frame or, after a disconnect, into the user's shell. When the underlying
+// writer collects frames (see FrameWriter), its frame is flushed as well.
+// Returns the first write error of the frame, including errors from
+// mid-render writes.
 func (cw *ChunkWriter) Flush() error {
-	data := cw.buf
-	cw.buf = cw.buf[:0] // Reset length, keep capacity
-	for len(data) > 0 {
-		chunk := data
-		if len(chunk) > maxChunkSize {
-			chunk = data[:maxChunkSize]
+	if cw.buf != nil {
+		cw.buf = append(cw.buf, ColorReset...)
+		cw.writeOut()
+		if buf := cw.buf; cap(buf) <= 2*maxFrameBufSize {
+			frameBufPool.Put(&buf)
 		}
-		if _, err := cw.bufw.Write(chunk); err != nil {
-			return err
+		cw.buf = nil
+	}
+	cw.sent = 0
+	err := cw.err
+	cw.err = nil
+	if f, ok := cw.w.(flusher); ok {
+		if ferr := f.Flush(); err == nil {
+			err = ferr
 		}
-		data = data[len(chunk):]
 	}
-	return cw.bufw.Flush()
+	return err
+}
+
+// flusher is implemented by writers that collect output into whole frames
+// (see FrameWriter); ChunkWriter.Flush ends their frame too.
+type flusher interface {
+	Flush() error
+}
+
+// FrameWriter writes to an underlying writer on its own goroutine, so a slow
+// reader (e.g. a full SSH send window) stalls only that goroutine instead of
+// the caller. Writes are collected into a frame that Flush hands to the
+// writer goroutine as a whole; Flush never blocks. At most one complete frame
+// waits behind the one being sent: flushing another replaces it, and Dropped
+// reports that, so the caller can redraw in full. Frames are never sent in
+// part. Busy reports whether earlier frames are still being sent, so a render
+// loop can skip a frame rather than queue it. Write and Flush must be called
+// from one goroutine; Close must be called when done.
+type FrameWriter struct {
+	w       io.Writer
+	frame   []byte       // Frame being collected by Write; nil until the first write
+	queue   chan []byte  // The newest complete frame waiting to be sent
+	pending atomic.Int32 // Frames queued or in flight
+	dropped atomic.Bool  // A queued frame was discarded since the last Dropped call
+	done    chan struct{}
+
+	mu  sync.Mutex
+	err error // First error from the underlying writer
+}
+
+// frameQueueSize is the number of complete frames a FrameWriter holds behind
+// the one being sent. Older frames are stale once a newer one is ready.
+const frameQueueSize = 1
+
+// NewFrameWriter creates a FrameWriter that writes to w and starts its
+// writer goroutine.
+func NewFrameWriter(w io.Writer) *FrameWriter {
+	fw := &FrameWriter{
+		w:     w,
+		queue: make(chan []byte, frameQueueSize),
+		done:  make(chan struct{}),
+	}
+	go fw.run()
+	return fw
+}
+
+// run sends queued frames until Close. After the first error, further output
+// is discarded.
+func (fw *FrameWriter) run() {
+	defer close(fw.done)
+	for buf := range fw.queue {
+		if fw.Err() == nil {
+			if _, err := fw.w.Write(buf); err != nil {
+				fw.mu.Lock()
+				fw.err = err
+				fw.mu.Unlock()
+			}
+		}
+		fw.release(buf)
+	}
+}
+
+// release returns a sent or discarded frame's buffer to the pool.
+func (fw *FrameWriter) release(buf []byte) {
+	if cap(buf) <= 2*maxFrameBufSize {
+		frameBufPool.Put(&buf)
+	}
+	fw.pending.Add(-1)
+}
+
+// Write appends p to the current frame; nothing is sent before Flush. Errors
+// from the underlying writer are reported by the next Write after they occur.
+func (fw *FrameWriter) Write(p []byte) (int, error) {
+	if err := fw.Err(); err != nil {
+		return 0, err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if fw.frame == nil {
+		fw.frame = (*frameBufPool.Get().(*[]byte))[:0]
+	}
+	fw.frame = append(fw.frame, p...)
+	return len(p), nil
+}
+
+// Flush queues the current frame without blocking. A frame still waiting
+// from an earlier Flush is discarded in its favor (see Dropped).
+func (fw *FrameWriter) Flush() error {
+	if err := fw.Err(); err != nil {
+		return err
+	}
+	buf := fw.frame
+	if len(buf) == 0 {
+		return nil
+	}
+	fw.frame = nil
+	fw.pending.Add(1)
+	for {
+		select {
+		case fw.queue <- buf:
+			return nil
+		default:
+		}
+		select {
+		case old := <-fw.queue:
+			fw.dropped.Store(true)
+			fw.release(old)
+		default:
+		}
+	}
+}
+
+// Dropped reports whether a queued frame was discarded since the last call.
+// Screen content built from diffs is then out of sync with the terminal and
+// should be redrawn in full.
+func (fw *FrameWriter) Dropped() bool {
+	return fw.dropped.Swap(false)
+}
+
+// Busy reports whether earlier frames are still queued or being sent.
+func (fw *FrameWriter) Busy() bool {
+	return fw.pending.Load() > 0
+}
+
+// Err returns the first error from the underlying writer, if any.
+func (fw *FrameWriter) Err() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return fw.err
+}
+
+// Close flushes the current frame, waits for queued frames to be sent and
+// stops the writer goroutine. Write must not be called after Close.
+func (fw *FrameWriter) Close() error {
+	fw.Flush()
+	close(fw.queue)
+	<-fw.done
+	return fw.Err()
+}
+
+// IsDisconnect reports whether err from writing to a terminal means the other
+// end has gone away (closed SSH channel, broken pipe, reset connection) rather
+// than an unexpected failure. Such errors end a session normally.
+func IsDisconnect(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET)
 }
 
 // TermSizeFunc is a function that returns the terminal dimensions.
@@ -125,6 +353,12 @@ func ShowCursor(w io.Writer) {
 	io.WriteString(w, "\033[?25h")
 }
 
+// ResetTerminal resets colors, shows the cursor and clears the screen,
+// leaving the terminal as a shell expects it.
+func ResetTerminal(w io.Writer) {
+	io.WriteString(w, ColorReset+"\033[?25h\033[H\033[2J")
+}
+
 // TerminalSizeRawWith returns actual terminal dimensions using the provided size function.
 func TerminalSizeRawWith(sizeFunc TermSizeFunc) (width, height int, err error) {
 	return sizeFunc()
@@ -0,0 +1,168 @@
+package draw
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// gatedWriter blocks each Write until the test releases it, simulating a
+// terminal that reads slowly.
+type gatedWriter struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	writes int
+	gate   chan struct{}
+	start  chan struct{} // Receives a value as each Write begins
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	g.start <- struct{}{}
+	<-g.gate
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.writes++
+	return g.buf.Write(p)
+}
+
+func TestFrameWriterSendsWholeFrames(t *testing.T) {
+	g := &gatedWriter{gate: make(chan struct{}), start: make(chan struct{}, 8)}
+	fw := NewFrameWriter(g)
+
+	// A frame larger than a ChunkWriter chunk arrives in several writes
+	cw := NewChunkWriter(fw, 0, 0)
+	big := strings.Repeat("a", 3*maxFrameBufSize)
+	cw.WriteString(big)
+	if err := cw.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	<-g.start // Frame 1 is in flight
+
+	cw.WriteString("second")
+	cw.Flush() // Waits behind frame 1
+	cw.WriteString("third")
+	cw.Flush() // Replaces frame 2
+
+	if !fw.Busy() {
+		t.Error("Busy() = false while frames are queued")
+	}
+	close(g.gate)
+	if err := fw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if !fw.Dropped() {
+		t.Error("Dropped() = false after a queued frame was replaced")
+	}
+	want := big + ColorReset + "third" + ColorReset
+	if got := g.buf.String(); got != want {
+		t.Errorf("sent %d bytes in %d writes, want only frames 1 and 3 (%d bytes)", len(got), g.writes, len(want))
+	}
+	if g.writes != 2 {
+		t.Errorf("writes = %d, want 2 (one per frame)", g.writes)
+	}
+}
+
+func TestFrameWriterHoldsOutputUntilFlush(t *testing.T) {
+	var buf bytes.Buffer
+	fw := NewFrameWriter(&buf)
+	fw.Write([]byte("partial"))
+	if fw.Busy() {
+		t.Error("Busy() = true before Flush")
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := buf.String(); got != "partial" {
+		t.Errorf("Close sent %q, want the unflushed frame %q", got, "partial")
+	}
+}
+
+// frameRecorder collects ChunkWriter output into frames, one per Flush, and
+// counts the writes that made up each frame.
+type frameRecorder struct {
+	cur    []byte
+	writes int
+	frames []recordedFrame
+}
+
+type recordedFrame struct {
+	data   []byte
+	writes int
+}
+
+func (r *frameRecorder) Write(p []byte) (int, error) {
+	r.cur = append(r.cur, p...)
+	r.writes++
+	return len(p), nil
+}
+
+func (r *frameRecorder) Flush() error {
+	r.frames = append(r.frames, recordedFrame{r.cur, r.writes})
+	r.cur, r.writes = nil, 0
+	return nil
+}
+
+// sgrPattern matches SGR (color and intensity) sequences.
+var sgrPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func TestFramesEndWithSGRReset(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		maxFrameBytes int
+		chunked       bool // Whether the first frame must span several writes
+	}{
+		{"small", 40, 10, 0, false},
+		{"written out mid-render", 240, 80, 0, true},
+		{"cut off by the byte budget", 80, 24, 500, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewScaledCanvas(tt.width, tt.height, float64(tt.width), float64(tt.height*2))
+			c.SetScanlines(true)
+			c.SetMaxFrameBytes(tt.maxFrameBytes)
+			rec := &frameRecorder{}
+			cw := NewChunkWriter(rec, 0, 0)
+
+			colors := []string{ColorBrightRed, Color256(202), TrueColor(10, 200, 30)}
+			for frame, color := range colors {
+				c.Clear()
+				c.SetAccent(color)
+				for y := range tt.height * 2 {
+					for x := range tt.width {
+						if (x+y+frame)%3 != 0 {
+							c.Set(x, y)
+						}
+					}
+				}
+				c.Render(cw)
+				// UI text whose color is never closed
+				cw.WriteString(color)
+				cw.WriteString("HUD")
+				if err := cw.Flush(); err != nil {
+					t.Fatalf("Flush: %v", err)
+				}
+			}
+
+			if len(rec.frames) != len(colors) {
+				t.Fatalf("flushed %d frames, want %d", len(rec.frames), len(colors))
+			}
+			if tt.chunked && rec.frames[0].writes < 2 {
+				t.Errorf("first frame sent in %d writes, want it split mid-render", rec.frames[0].writes)
+			}
+			for i, f := range rec.frames {
+				sgrs := sgrPattern.FindAll(f.data, -1)
+				if len(sgrs) == 0 {
+					t.Errorf("frame %d has no SGR sequences", i)
+					continue
+				}
+				if last := string(sgrs[len(sgrs)-1]); last != ColorReset {
+					t.Errorf("frame %d: last SGR is %q, want the reset %q", i, last, ColorReset)
+				}
+			}
+		})
+	}
+}
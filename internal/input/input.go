@@ -2,62 +2,131 @@ package input
 
 import (
 	"bufio"
+	"fmt"
 	"time"
+
+	"github.com/tomz197/asteroids/internal/clock"
+)
+
+// Hold durations: how long a key counts as "held" after its last byte.
+// Terminals send no key-up events, so a held key is seen as a stream of
+// repeated bytes and is released once they stop for this long. Longer keeps
+// held movement smooth when an SSH link delivers bytes in bursts, but a
+// released key lingers for up to that long; shorter releases crisply but
+// stutters on bursty links.
+const (
+	DefaultHoldDuration = 30 * time.Millisecond
+	MinHoldDuration     = 5 * time.Millisecond
+	MaxHoldDuration     = 500 * time.Millisecond
 )
 
-// keyHoldDuration is how long a key is considered "held" after its last press.
-const keyHoldDuration = 30 * time.Millisecond
+// pressGap is the pause after which a Space byte counts as a new press
+// rather than key repeat. Repeat bytes arrive every 30-50ms once repeating
+// starts, but the first one follows the terminal's repeat delay (often
+// 250-600ms) and can't be told apart from a second tap, so holding Space
+// reads as at most two presses.
+const pressGap = 100 * time.Millisecond
+
+// HoldDurations sets the hold window per key category. Keys outside these
+// categories (quit, enter, escape, chat, ...) are one-shot: they count as
+// pressed only in the frame their byte arrives.
+type HoldDurations struct {
+	Movement time.Duration // Thrust, rotate and brake; 0 selects DefaultHoldDuration
+	Fire     time.Duration // Shooting (Input.Fire); 0 fires only in frames a Space byte arrives
+}
+
+// ParseHoldDurations parses the movement and fire hold durations, each like
+// "50ms". Movement ranges from MinHoldDuration to MaxHoldDuration and
+// defaults to DefaultHoldDuration; fire ranges from 0 to MaxHoldDuration and
+// defaults to 0. Empty strings select the defaults.
+func ParseHoldDurations(movement, fire string) (HoldDurations, error) {
+	var h HoldDurations
+	if movement != "" {
+		d, err := time.ParseDuration(movement)
+		if err != nil || d < MinHoldDuration || d > MaxHoldDuration {
+			return HoldDurations{}, fmt.Errorf("invalid movement hold duration %q: must be from %v to %v", movement, MinHoldDuration, MaxHoldDuration)
+		}
+		h.Movement = d
+	}
+	if fire != "" {
+		d, err := time.ParseDuration(fire)
+		if err != nil || d < 0 || d > MaxHoldDuration {
+			return HoldDurations{}, fmt.Errorf("invalid fire hold duration %q: must be from 0 to %v", fire, MaxHoldDuration)
+		}
+		h.Fire = d
+	}
+	return h, nil
+}
 
 // Input represents the current frame's input state.
 type Input struct {
-	Quit      bool
-	Left      bool
-	Right     bool
-	UpLeft    bool
-	UpRight   bool
-	Up        bool
-	Down      bool
-	Space     bool
-	Enter     bool
-	Backspace bool
-	Delete    bool
-	Escape    bool
-	Chat      bool
-	Number    int
-	Pressed   []byte
+	Quit       bool
+	Left       bool
+	Right      bool
+	UpLeft     bool
+	UpRight    bool
+	Up         bool
+	Down       bool
+	Space      bool // Pressed this frame; for menus and other one-shot actions
+	Fire       bool // Space held within the fire hold window; for shooting
+	FirePress  bool // Space pressed after a pause (see pressGap): a new press, not key repeat
+	Enter      bool
+	Backspace  bool
+	Delete     bool
+	Escape     bool
+	Chat       bool
+	Settings   bool
+	Help       bool
+	Tab        bool
+	Hyperspace bool // Pressed this frame; one jump per press
+	Number     int
+	Pressed    []byte
 }
 
 // keyState tracks the last time each key was pressed.
 type keyState struct {
-	quit      time.Time
-	left      time.Time
-	right     time.Time
-	upLeft    time.Time
-	upRight   time.Time
-	up        time.Time
-	down      time.Time
-	space     time.Time
-	enter     time.Time
-	backspace time.Time
-	delete_   time.Time
-	escape    time.Time
-	chat      time.Time
-	number    time.Time
-	numberVal int
+	quit       time.Time
+	left       time.Time
+	right      time.Time
+	upLeft     time.Time
+	upRight    time.Time
+	up         time.Time
+	down       time.Time
+	space      time.Time
+	enter      time.Time
+	backspace  time.Time
+	delete_    time.Time
+	escape     time.Time
+	chat       time.Time
+	settings   time.Time
+	help       time.Time
+	tab        time.Time
+	hyperspace time.Time
+	number     time.Time
+	numberVal  int
 }
 
 // Stream delivers input bytes via a channel and tracks key state for combinations.
 type Stream struct {
 	ch    chan byte
 	state keyState
-	buf   []byte // Reusable drain buffer (reset to [:0] each frame)
+	buf   []byte      // Reusable drain buffer (reset to [:0] each frame)
+	clock clock.Clock // Timestamps key presses; the wall clock unless replaced
+	hold  HoldDurations
 }
 
 // StartStream spawns a goroutine that reads from r and sends bytes to the stream.
-func StartStream(r *bufio.Reader) *Stream {
+// Keys count as held for their category's hold duration after their last byte.
+func StartStream(r *bufio.Reader, hold HoldDurations) *Stream {
+	if hold.Movement <= 0 {
+		hold.Movement = DefaultHoldDuration
+	}
+	hold.Fire = max(hold.Fire, 0)
 	s := &Stream{
 		ch:    make(chan byte, 128),
 		state: keyState{numberVal: -1},
+		clock: clock.Real,
+		hold:  hold,
 	}
 	go func() {
 		for {
@@ -72,12 +141,24 @@ func StartStream(r *bufio.Reader) *Stream {
 	return s
 }
 
+// HoldDurations returns how long keys count as held after their last byte.
+func (s *Stream) HoldDurations() HoldDurations {
+	return s.hold
+}
+
+// SetClock replaces the clock used to timestamp key presses and expire held
+// keys. Nil restores the wall clock.
+func (s *Stream) SetClock(c clock.Clock) {
+	s.clock = clock.Or(c)
+}
+
 // ReadInput drains all available bytes from the stream (non-blocking).
 // Handles escape sequences for arrow keys and accumulates all pressed keys.
 // Uses key state persistence to allow detecting simultaneous key combinations.
 // Note: Input.Pressed references an internal buffer valid only until the next ReadInput call.
 func ReadInput(s *Stream) Input {
-	now := time.Now()
+	now := s.clock.Now()
+	prevSpace := s.state.space
 	buf := s.buf[:0]
 
 	// Drain all available bytes
@@ -98,6 +179,18 @@ drain:
 	for i := 0; i < len(buf); i++ {
 		b := buf[i]
 
+		// F1: ESC O P (xterm) or ESC [ 1 1 ~ (vt220)
+		if b == '\x1b' && i+2 < len(buf) && buf[i+1] == 'O' && buf[i+2] == 'P' {
+			s.state.help = now
+			i += 2
+			continue
+		}
+		if b == '\x1b' && i+4 < len(buf) && buf[i+1] == '[' && buf[i+2] == '1' && buf[i+3] == '1' && buf[i+4] == '~' {
+			s.state.help = now
+			i += 4
+			continue
+		}
+
 		// Check for escape sequences (arrow keys, etc.)
 		if b == '\x1b' && i+2 < len(buf) && buf[i+1] == '[' {
 			// CSI sequence: ESC [ <code>
@@ -125,27 +218,34 @@ drain:
 		applyByteToState(&s.state, b, now)
 	}
 
-	// Build input from key state - keys are "pressed" if seen within hold duration
+	// Build input from key state - held keys are "pressed" if seen within their
+	// category's hold duration, one-shot keys only in the frame they arrived
 	input := Input{
-		Quit:      s.state.quit.Equal(now),
-		Left:      now.Sub(s.state.left) < keyHoldDuration,
-		Right:     now.Sub(s.state.right) < keyHoldDuration,
-		UpLeft:    now.Sub(s.state.upLeft) < keyHoldDuration,
-		UpRight:   now.Sub(s.state.upRight) < keyHoldDuration,
-		Up:        now.Sub(s.state.up) < keyHoldDuration,
-		Down:      now.Sub(s.state.down) < keyHoldDuration,
-		Space:     s.state.space.Equal(now),
-		Enter:     s.state.enter.Equal(now),
-		Backspace: s.state.backspace.Equal(now),
-		Delete:    s.state.delete_.Equal(now),
-		Escape:    s.state.escape.Equal(now),
-		Chat:      s.state.chat.Equal(now),
-		Number:    -1,
-		Pressed:   buf,
+		Quit:       s.state.quit.Equal(now),
+		Left:       now.Sub(s.state.left) < s.hold.Movement,
+		Right:      now.Sub(s.state.right) < s.hold.Movement,
+		UpLeft:     now.Sub(s.state.upLeft) < s.hold.Movement,
+		UpRight:    now.Sub(s.state.upRight) < s.hold.Movement,
+		Up:         now.Sub(s.state.up) < s.hold.Movement,
+		Down:       now.Sub(s.state.down) < s.hold.Movement,
+		Space:      s.state.space.Equal(now),
+		Fire:       s.state.space.Equal(now) || now.Sub(s.state.space) < s.hold.Fire,
+		FirePress:  s.state.space.Equal(now) && now.Sub(prevSpace) >= pressGap,
+		Enter:      s.state.enter.Equal(now),
+		Backspace:  s.state.backspace.Equal(now),
+		Delete:     s.state.delete_.Equal(now),
+		Escape:     s.state.escape.Equal(now),
+		Chat:       s.state.chat.Equal(now),
+		Settings:   s.state.settings.Equal(now),
+		Help:       s.state.help.Equal(now),
+		Tab:        s.state.tab.Equal(now),
+		Hyperspace: s.state.hyperspace.Equal(now),
+		Number:     -1,
+		Pressed:    buf,
 	}
 
-	// Number is only set if recently pressed
-	if now.Sub(s.state.number) < keyHoldDuration {
+	// Number is only set if pressed this frame
+	if s.state.number.Equal(now) {
 		input.Number = s.state.numberVal
 	}
 
@@ -192,8 +292,16 @@ func applyByteToState(state *keyState, b byte, now time.Time) {
 		state.delete_ = now
 	case '\x1b':
 		state.escape = now
-	case 'c', 'C':
+	case 'c', 'C', 't', 'T':
 		state.chat = now
+	case 'm', 'M':
+		state.settings = now
+	case '?':
+		state.help = now
+	case '\t':
+		state.tab = now
+	case 'h', 'H':
+		state.hyperspace = now
 	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 		state.number = now
 		state.numberVal = int(b - '0')
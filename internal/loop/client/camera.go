@@ -0,0 +1,39 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+)
+
+// Largest camera deadzone half-sizes: a quarter of the view each way, so the
+// ship always stays well inside the screen.
+const (
+	maxCameraDeadzoneX = config.ViewWidth / 4
+	maxCameraDeadzoneY = config.ViewHeight / 4
+)
+
+// CameraDeadzone is the half-size, in world units, of a box around the view
+// center the ship can move in without the camera following, for a steadier
+// view while maneuvering. The zero value keeps the camera locked on the ship.
+type CameraDeadzone struct {
+	X, Y float64
+}
+
+// ParseCameraDeadzone parses a deadzone such as "20x10" ("" keeps the camera
+// locked on the ship).
+func ParseCameraDeadzone(s string) (CameraDeadzone, error) {
+	if s == "" {
+		return CameraDeadzone{}, nil
+	}
+	// Split by hand: Sscanf's %g would read "0x0" as a hex number
+	xs, ys, _ := strings.Cut(s, "x")
+	x, errX := strconv.ParseFloat(xs, 64)
+	y, errY := strconv.ParseFloat(ys, 64)
+	if errX != nil || errY != nil || !(x >= 0 && x <= maxCameraDeadzoneX) || !(y >= 0 && y <= maxCameraDeadzoneY) {
+		return CameraDeadzone{}, fmt.Errorf("camera deadzone must look like 20x10, at most %dx%d (got %q)", maxCameraDeadzoneX, maxCameraDeadzoneY, s)
+	}
+	return CameraDeadzone{X: x, Y: y}, nil
+}
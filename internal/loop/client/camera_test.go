@@ -0,0 +1,50 @@
+package client
+
+import "testing"
+
+func TestParseCameraDeadzone(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    CameraDeadzone
+		wantErr bool
+	}{
+		{"", CameraDeadzone{}, false},
+		{"0x0", CameraDeadzone{}, false},
+		{"20x10", CameraDeadzone{X: 20, Y: 10}, false},
+		{"7.5x2.5", CameraDeadzone{X: 7.5, Y: 2.5}, false},
+		{"30x20", CameraDeadzone{X: 30, Y: 20}, false},
+		{"31x10", CameraDeadzone{}, true}, // Wider than a quarter of the view
+		{"20x21", CameraDeadzone{}, true},
+		{"-1x5", CameraDeadzone{}, true},
+		{"NaNx5", CameraDeadzone{}, true},
+		{"20", CameraDeadzone{}, true},
+		{"wide", CameraDeadzone{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCameraDeadzone(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseCameraDeadzone(%q) = %+v, %v; want %+v, error %t", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFollowAxis(t *testing.T) {
+	tests := []struct {
+		name                        string
+		cam, target, deadzone, size float64
+		want                        float64
+	}{
+		{"locked", 50, 53, 0, 400, 53},
+		{"inside the deadzone", 50, 58, 10, 400, 50},
+		{"on the deadzone edge", 50, 40, 10, 400, 50},
+		{"past the right edge", 50, 65, 10, 400, 55},
+		{"past the left edge", 50, 35, 10, 400, 45},
+		{"across the wrap", 395, 12, 10, 400, 402}, // The short way is 17 to the right
+		{"bounded world", 395, 12, 10, 0, 22},
+	}
+	for _, tt := range tests {
+		if got := followAxis(tt.cam, tt.target, tt.deadzone, tt.size); got != tt.want {
+			t.Errorf("%s: followAxis(%g, %g, %g, %g) = %g, want %g", tt.name, tt.cam, tt.target, tt.deadzone, tt.size, got, tt.want)
+		}
+	}
+}
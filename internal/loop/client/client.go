@@ -3,38 +3,80 @@ package client
 import (
 	"bufio"
 	"io"
+	"log"
+	"math"
 	"strings"
 	"time"
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/tomz197/asteroids/internal/clock"
 	"github.com/tomz197/asteroids/internal/draw"
 	"github.com/tomz197/asteroids/internal/input"
 	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/loop/pacing"
 	"github.com/tomz197/asteroids/internal/loop/server"
 	"github.com/tomz197/asteroids/internal/object"
 )
 
 // Client handles rendering and input for a single connection.
 type Client struct {
-	server       server.GameServer
-	handle       *server.ClientHandle
-	state        *ClientState
-	canvas       *draw.Canvas
-	chunkWriter  *draw.ChunkWriter // Accumulates UI text for chunked output
-	reader       *bufio.Reader
-	writer       io.Writer
-	inputStream  *input.Stream
-	lastInput    time.Time
-	username     string
-	termSizeFunc draw.TermSizeFunc
-	hudBuf       []byte // Reusable buffer for HUD text formatting
+	server         server.GameServer
+	handle         *server.ClientHandle
+	state          *ClientState
+	canvas         *draw.Canvas
+	chunkWriter    *draw.ChunkWriter // Accumulates UI text for chunked output
+	reader         *bufio.Reader
+	writer         *draw.FrameWriter // Sends output off the game loop (see draw.FrameWriter)
+	inputStream    *input.Stream
+	lastInput      time.Time
+	lastScored     time.Time // When points were last awarded, for idle detection
+	username       string
+	termSizeFunc   draw.TermSizeFunc
+	hudBuf         []byte                 // Reusable buffer for HUD text formatting
+	colorLevel     draw.ColorLevel        // Terminal color support
+	colors         uiColors               // Active theme resolved for colorLevel
+	drawErrors     int                    // Object draw errors since the last log line
+	droppedFrames  int                    // Frames skipped because the terminal was still busy
+	lastDrawErrLog time.Time              // When an object draw error was last logged
+	version        string                 // Build version shown on the title screen
+	snapshot       *server.WorldSnapshot  // World snapshot for the current frame
+	spawnRegion    server.SpawnRegion     // Requested spawn area (zero = anywhere)
+	particleStyle  object.ParticleStyle   // How particles are drawn
+	bulletStyle    object.ProjectileStyle // How projectiles are drawn
+	pausable       bool                   // Escape pauses the server (private single-player server)
+	killCam        *killCam               // Recent snapshots, replayed after a death
+	frameTime      time.Duration          // Target time per frame
+	minimap        *minimap               // Minimap layout and buffers
+	deadzone       CameraDeadzone         // Camera deadzone around the ship (see followAxis)
+	clock          clock.Clock            // Source of the current time (see ClientOptions.Clock)
+	pingFunc       func() time.Duration   // Round-trip time source (see ClientOptions.PingFunc)
+	killCamTarget  object.Object          // What killed the player, highlighted in the replay frame
 }
 
 // ClientOptions configures the client.
 type ClientOptions struct {
-	TermSizeFunc draw.TermSizeFunc
-	Username     string
+	TermSizeFunc  draw.TermSizeFunc
+	Username      string
+	Theme         string                 // Built-in theme name (see ThemeByName); "" selects the default
+	ColorLevel    draw.ColorLevel        // Terminal color support (see draw.DetectColorLevel)
+	Scanlines     bool                   // CRT mode: dim every other terminal row
+	ASCII         bool                   // Draw the canvas with ASCII characters instead of half-blocks
+	Braille       bool                   // Draw the canvas with Braille dots (2x4 per cell) for finer detail; needs a font with them
+	MaxFrameBytes int                    // Cap on canvas bytes per frame for slow links (0 = unlimited)
+	Ship          object.ShipShape       // Initially selected ship silhouette
+	Version       string                 // Build version shown on the title screen ("" hides it)
+	SpawnQuadrant server.Quadrant        // World quadrant to ask the server to spawn ships in; QuadrantNone means anywhere
+	ParticleStyle object.ParticleStyle   // How explosion and thrust particles are drawn
+	BulletStyle   object.ProjectileStyle // How projectiles are drawn
+	Pausable      bool                   // Let Escape pause the world; only for a private single-player server
+	FrameTime     time.Duration          // Target time per frame; 0 selects config.ClientTargetFrameTime
+	Minimap       MinimapOptions         // Minimap size and corner; zero keeps 20x10 top-right
+	Deadzone      CameraDeadzone         // Box the ship moves in before the camera follows; zero locks the camera on the ship
+	Clock         clock.Clock            // Time source for timers, input and blinking; nil is the wall clock
+	KeyHold       input.HoldDurations    // How long keys count as held after their last byte, per key category
+	PingFunc      func() time.Duration   // Latest round-trip time to the player's terminal (0 = unknown); nil hides the ping
+	Preferences   server.Preferences     // Initial control preferences (fire mode, turn ramp), changeable in settings
 }
 
 // NewClient creates a new client connected to the given server.
@@ -44,9 +86,13 @@ func NewClient(gs server.GameServer, r *bufio.Reader, w io.Writer, opts ClientOp
 		termSizeFunc = draw.DefaultTermSizeFunc
 	}
 
-	handle := gs.RegisterClient(opts.Username)
+	handle := gs.RegisterClient(opts.Username, opts.Preferences)
 	state := NewClientState()
 	state.termSizeFunc = termSizeFunc
+	if opts.Username == "" {
+		state.GameState = GameStateNameEntry
+	}
+	state.PersonalBest = handle.PersonalBest
 
 	// Set up view dimensions
 	state.View = object.Screen{
@@ -57,43 +103,96 @@ func NewClient(gs server.GameServer, r *bufio.Reader, w io.Writer, opts ClientOp
 	}
 
 	// Camera starts at world center
+	world := gs.GetSnapshot().World
 	state.Camera = object.Camera{
-		X: float64(config.WorldWidth) / 2,
-		Y: float64(config.WorldHeight) / 2,
+		X: float64(world.Width) / 2,
+		Y: float64(world.Height) / 2,
 	}
 
 	// Create canvas with clamped dimensions for max render resolution
-	termWidth, termHeight, _ := draw.TerminalSizeRawWith(termSizeFunc)
+	termWidth, termHeight, err := draw.TerminalSizeRawWith(termSizeFunc)
+	if err != nil || !validTermSize(termWidth, termHeight) {
+		termWidth, termHeight = config.DefaultTermWidth, config.DefaultTermHeight
+	}
 	renderWidth, renderHeight, offsetCol, offsetRow := clampTermSize(termWidth, termHeight)
 	canvas := draw.NewScaledCanvas(renderWidth, renderHeight, config.ViewWidth, config.ViewHeight)
 	canvas.SetOffset(offsetCol, offsetRow)
-	chunkWriter := draw.NewChunkWriter(w, offsetCol, offsetRow)
+	canvas.SetScanlines(opts.Scanlines)
+	canvas.SetASCII(opts.ASCII)
+	canvas.SetBraille(opts.Braille)
+	canvas.SetColorLevel(opts.ColorLevel)
+	canvas.SetMaxFrameBytes(opts.MaxFrameBytes)
+	out := draw.NewFrameWriter(w)
+	chunkWriter := draw.NewChunkWriter(out, offsetCol, offsetRow)
+
+	themeIdx, _ := ThemeByName(opts.Theme)
+	state.ThemeIndex = themeIdx
+	state.Ship = opts.Ship
+	state.Prefs = handle.Prefs
+
+	frameTime := opts.FrameTime
+	if frameTime <= 0 {
+		frameTime = config.ClientTargetFrameTime
+	}
+
+	clk := clock.Or(opts.Clock)
+	stream := input.StartStream(r, opts.KeyHold)
+	stream.SetClock(clk)
 
 	return &Client{
-		server:       gs,
-		handle:       handle,
-		state:        state,
-		canvas:       canvas,
-		chunkWriter:  chunkWriter,
-		reader:       r,
-		writer:       w,
-		lastInput:    time.Now(),
-		inputStream:  input.StartStream(r),
-		username:     opts.Username,
-		termSizeFunc: termSizeFunc,
+		server:        gs,
+		handle:        handle,
+		state:         state,
+		canvas:        canvas,
+		chunkWriter:   chunkWriter,
+		reader:        r,
+		writer:        out,
+		lastInput:     clk.Now(),
+		inputStream:   stream,
+		username:      opts.Username,
+		termSizeFunc:  termSizeFunc,
+		colorLevel:    opts.ColorLevel,
+		colors:        themes[themeIdx].resolve(opts.ColorLevel),
+		version:       opts.Version,
+		spawnRegion:   server.QuadrantRegion(world, opts.SpawnQuadrant),
+		particleStyle: opts.ParticleStyle,
+		bulletStyle:   opts.BulletStyle,
+		pausable:      opts.Pausable,
+		killCam:       newKillCam(),
+		frameTime:     frameTime,
+		minimap:       newMinimap(opts.Minimap),
+		deadzone:      opts.Deadzone,
+		clock:         clk,
+		pingFunc:      opts.PingFunc,
 	}
 }
 
+// since returns the time elapsed since t on the client's clock.
+func (c *Client) since(t time.Time) time.Duration {
+	return c.clock.Now().Sub(t)
+}
+
 // Run starts the client loop. Blocks until the client disconnects or server stops.
+// A write failing because the terminal went away (see draw.IsDisconnect) ends
+// the session normally and returns nil; other write errors are returned.
+//
+// Output is sent by a separate goroutine one whole frame at a time, so the
+// loop never blocks on the terminal. While it is still busy with earlier
+// output (a slow reader), frames are skipped rather than rendered; if a frame
+// is finished while an older one is still waiting anyway, the older one is
+// discarded and the next frame is a full redraw. Either way input and server
+// events keep being processed on time.
 func (c *Client) Run() error {
+	defer c.cleanup()
+
 	draw.HideCursor(c.writer)
-	defer draw.ShowCursor(c.writer)
 	draw.ClearScreen(c.writer)
 
-	lastTime := time.Now()
+	lastTime := c.clock.Now()
+	pacer := pacing.New(c.frameTime)
 
 	for c.state.Running {
-		frameStart := time.Now()
+		frameStart := c.clock.Now()
 		c.state.delta = frameStart.Sub(lastTime)
 		lastTime = frameStart
 
@@ -102,10 +201,15 @@ func (c *Client) Run() error {
 
 		// Check for server events
 		c.processServerEvents()
+		c.updateToast()
+		c.updateWaveBanner()
 
 		// Handle screen resize
 		c.updateScreen()
 
+		// One snapshot per frame, so the camera and the drawn world agree
+		c.snapshot = c.server.GetSnapshotFor(c.handle.ID)
+
 		// Handle game state
 		switch c.state.GameState {
 		case GameStateStart:
@@ -116,43 +220,77 @@ func (c *Client) Run() error {
 			c.updateDeadState()
 		case GameStateShutdown:
 			c.updateShutdownState()
+		case GameStateSummary:
+			c.updateSummaryState()
 		}
 
-		// Cursor visibility: show when chat is open for typing
-		if c.state.ChatOpen {
-			draw.ShowCursor(c.writer)
-		} else {
-			draw.HideCursor(c.writer)
-		}
-
-		// Draw frame
-		if err := c.drawFrame(); err != nil {
+		// The server builds the next snapshot around this camera position
+		c.handle.SetCamera(c.state.Camera.X, c.state.Camera.Y)
+
+		// Draw frame, unless the last one is still being sent. The canvas
+		// diffs against what was last rendered, so a skipped frame is
+		// covered by the next one.
+		if c.writer.Busy() {
+			c.droppedFrames++
+		} else if err := c.drawFrame(); err != nil {
+			if draw.IsDisconnect(err) {
+				return nil
+			}
 			return err
 		}
 
 		// Frame timing
-		elapsed := time.Since(frameStart)
-		if elapsed < config.ClientTargetFrameTime {
-			time.Sleep(config.ClientTargetFrameTime - elapsed)
-		}
+		pacer.Wait()
 	}
+	return nil
+}
 
-	// Unregister from server
+// cleanup runs however Run exits: it leaves the terminal usable (colors
+// reset, cursor shown, screen cleared), waits for queued output to be sent,
+// and unregisters from the server.
+func (c *Client) cleanup() {
+	draw.ResetTerminal(c.writer)
+	c.writer.Close()
+	if c.droppedFrames > 0 {
+		log.Printf("Dropped %d frames for client %d: terminal output backed up", c.droppedFrames, c.handle.ID)
+	}
 	c.server.UnregisterClient(c.handle.ID)
+}
 
-	draw.ClearScreen(c.writer)
-	return nil
+// activelyPlaying reports whether the player is in play without pressing keys:
+// their ship is moving at IdleMotionSpeed or more, or they scored within
+// IdleScoreWindow. Only applies while playing.
+func (c *Client) activelyPlaying() bool {
+	if c.state.GameState != GameStatePlaying || c.state.Paused {
+		return false
+	}
+	if config.IdleScoreWindow > 0 && c.since(c.lastScored) < config.IdleScoreWindow {
+		return true
+	}
+	if p := c.state.Player; p != nil && config.IdleMotionSpeed > 0 {
+		return math.Hypot(p.VX, p.VY) >= config.IdleMotionSpeed
+	}
+	return false
 }
 
 // processInput reads input and sends it to the server.
+//
+// Escape is the universal back/cancel key:
+//   - chat: discards the message and closes chat
+//   - settings and help overlays: closes them (and resumes a paused game)
+//   - playing: opens the help overlay, pausing the game when pausable
+//   - dead screen: returns to the start screen
+//   - summary: returns to the screen the player quit from
+//   - start screen: quits, like Q
 func (c *Client) processInput() {
+	c.state.prevInput = c.state.Input
 	c.state.Input = input.ReadInput(c.inputStream)
 
-	if len(c.state.Input.Pressed) > 0 {
-		c.lastInput = time.Now()
+	if len(c.state.Input.Pressed) > 0 || c.activelyPlaying() {
+		c.lastInput = c.clock.Now()
 		c.state.isInactive = false
 	} else {
-		idle := time.Since(c.lastInput).Seconds()
+		idle := c.since(c.lastInput).Seconds()
 		if idle > config.InactivityDisconnectUser {
 			c.state.Running = false
 		} else if idle > config.InactivityWarnUser {
@@ -169,6 +307,8 @@ func (c *Client) processInput() {
 			c.state.Input.Escape = false // Prevent same-frame game action (e.g. dead screen return)
 			return
 		}
+		// Keys typed in the same frame as Enter belong to the message
+		c.state.ChatInput = c.editText(c.state.ChatInput, config.MaxChatMessageLength)
 		if c.state.Input.Enter {
 			text := c.state.ChatInput
 			c.state.ChatOpen = false
@@ -176,37 +316,64 @@ func (c *Client) processInput() {
 			input.ResetKeyInput(c.inputStream)
 			c.state.Input.Enter = false // Prevent same-frame respawn/start
 			c.state.Input.Space = false
+			c.state.Input.Fire = false
+			c.state.Input.FirePress = false
 			if text != "" {
 				c.server.SendChatMessage(c.handle.ID, text)
 			}
-			return
 		}
-		if c.state.Input.Backspace || c.state.Input.Delete {
-			runes := []rune(c.state.ChatInput)
-			if len(runes) > 0 {
-				c.state.ChatInput = string(runes[:len(runes)-1])
+		return
+	}
+
+	// Name entry: every key types into the name field
+	if c.state.GameState == GameStateNameEntry {
+		c.updateNameEntryState()
+		return
+	}
+
+	// Settings overlay: navigation keys only
+	if c.state.SettingsOpen {
+		c.updateSettings()
+		return
+	}
+
+	// Help overlay: any key closes it without acting on the game
+	if c.state.HelpOpen {
+		if len(c.state.Input.Pressed) > 0 || c.state.GameState != GameStatePlaying {
+			c.state.HelpOpen = false
+			if c.state.Paused {
+				c.state.Paused = false
+				c.server.SetPaused(false)
 			}
-			return
+			input.ResetKeyInput(c.inputStream)
 		}
-		// Append printable runes from Pressed
-		printable := extractPrintableRunes(c.state.Input.Pressed)
-		if len(printable) > 0 {
-			var b strings.Builder
-			b.WriteString(c.state.ChatInput)
-			runeCount := utf8.RuneCountInString(c.state.ChatInput)
-			for _, r := range printable {
-				if runeCount >= config.MaxChatMessageLength {
-					break
-				}
-				b.WriteRune(r)
-				runeCount++
-			}
-			c.state.ChatInput = b.String()
+		return
+	}
+
+	// ?, F1 or Escape shows the controls while playing. The world keeps
+	// running unless this client may pause it.
+	if (c.state.Input.Help || c.state.Input.Escape) && c.state.GameState == GameStatePlaying {
+		c.state.HelpOpen = true
+		if c.pausable {
+			c.state.Paused = true
+			c.server.SetPaused(true)
 		}
+		c.server.SendInput(c.handle.ID, object.Input{Number: -1})
 		return
 	}
 
-	// C opens chat (when not already open)
+	// Tab shows or hides the leaderboard panel while playing
+	if c.state.Input.Tab && c.state.GameState == GameStatePlaying {
+		c.state.HideLeaderboard = !c.state.HideLeaderboard
+	}
+
+	// M opens settings on any screen that isn't closing down
+	if c.state.Input.Settings && c.state.GameState != GameStateShutdown && c.state.GameState != GameStateSummary {
+		c.openSettings()
+		return
+	}
+
+	// C or T opens chat (when not already open)
 	if c.state.Input.Chat {
 		c.state.ChatOpen = true
 		input.ResetKeyInput(c.inputStream)
@@ -214,7 +381,7 @@ func (c *Client) processInput() {
 	}
 
 	if c.state.Input.Quit {
-		c.state.Running = false
+		c.requestQuit()
 	}
 
 	// Send input to server if playing
@@ -223,6 +390,18 @@ func (c *Client) processInput() {
 	}
 }
 
+// isOwnShip reports whether u is this client's ship. Snapshots hold copies,
+// so ships are matched by owner rather than by pointer.
+func (c *Client) isOwnShip(u *object.User) bool {
+	return u.OwnerID == c.handle.ID
+}
+
+// inputCaptured reports whether an overlay (chat, settings or help) is
+// consuming input, so the per-state handlers must not act on it.
+func (c *Client) inputCaptured() bool {
+	return c.state.ChatOpen || c.state.SettingsOpen || c.state.HelpOpen
+}
+
 // extractPrintableRunes returns printable runes from raw input bytes, skipping control chars and escape sequences.
 func extractPrintableRunes(pressed []byte) []rune {
 	var result []rune
@@ -252,15 +431,37 @@ func (c *Client) processServerEvents() {
 			switch event.Type {
 			case server.EventPlayerDied:
 				c.state.Lives--
-				c.state.GameState = GameStateDead
+				switch {
+				case c.state.GameState == GameStateSummary:
+					c.state.summaryReturnState = GameStateDead // Keep the summary up
+				case c.state.GameState == GameStatePlaying && config.DeathCamTime > 0:
+					// Death cam: keep the view on the explosion before the dead screen
+					c.state.deathCamTime = config.DeathCamTime.Seconds()
+				case c.state.GameState == GameStatePlaying && c.killCam.start():
+					// Kill cam: replay the moments before the death
+				default:
+					c.state.GameState = GameStateDead
+				}
 				c.state.Player = nil
 				c.state.RespawnTimeRemaining = config.RespawnTimeout.Seconds()
 				c.state.KilledBy = event.KilledBy
+				c.state.Stats = event.Stats
+				if c.state.Lives <= 0 {
+					c.recordGameOver()
+				}
 			case server.EventScoreAdd:
 				c.state.Score += event.ScoreAdd
+				c.lastScored = c.clock.Now()
 			case server.EventServerShutdown:
 				c.state.GameState = GameStateShutdown
 				c.state.shutdownTimer = config.ShutdownDisplayTime.Seconds()
+			case server.EventAchievement:
+				c.showToast("Achievement unlocked: " + event.Achievement)
+			case server.EventWave:
+				c.state.waveBanner = event.Wave
+				c.state.waveBannerTime = config.WaveBannerTime.Seconds()
+			case server.EventHyperspace:
+				c.state.InvincibleTime = max(c.state.InvincibleTime, config.HyperspaceInvincible.Seconds())
 			}
 		default:
 			return
@@ -268,12 +469,41 @@ func (c *Client) processServerEvents() {
 	}
 }
 
+// showToast displays a transient notification for config.ToastDisplayTime.
+func (c *Client) showToast(text string) {
+	c.state.Toast = text
+	c.state.toastTime = config.ToastDisplayTime.Seconds()
+}
+
+// updateToast counts down the active toast and clears it when it expires.
+func (c *Client) updateToast() {
+	if c.state.Toast == "" {
+		return
+	}
+	c.state.toastTime -= c.state.delta.Seconds()
+	if c.state.toastTime <= 0 {
+		c.state.Toast = ""
+	}
+}
+
+// updateWaveBanner counts down the wave banner and clears it when it expires.
+func (c *Client) updateWaveBanner() {
+	if c.state.waveBanner == 0 {
+		return
+	}
+	c.state.waveBannerTime -= c.state.delta.Seconds()
+	if c.state.waveBannerTime <= 0 {
+		c.state.waveBanner = 0
+	}
+}
+
 // updateScreen handles terminal resize, clamping to max render resolution.
 // On actual size changes, clears the terminal to remove residual pixels
 // outside the new canvas area (e.g. old borders or offset content).
+// An error or zero size keeps the last good size.
 func (c *Client) updateScreen() {
 	termWidth, termHeight, err := draw.TerminalSizeRawWith(c.termSizeFunc)
-	if err != nil {
+	if err != nil || !validTermSize(termWidth, termHeight) {
 		return
 	}
 	renderWidth, renderHeight, offsetCol, offsetRow := clampTermSize(termWidth, termHeight)
@@ -289,6 +519,12 @@ func (c *Client) updateScreen() {
 	c.chunkWriter.SetOffset(offsetCol, offsetRow)
 }
 
+// validTermSize reports whether a reported terminal size is usable.
+// Some SSH clients report 0x0 before the real size arrives.
+func validTermSize(termWidth, termHeight int) bool {
+	return termWidth > 0 && termHeight > 0
+}
+
 // clampTermSize clamps terminal dimensions to the max render resolution and computes
 // the centering offset for the render area.
 func clampTermSize(termWidth, termHeight int) (renderWidth, renderHeight, offsetCol, offsetRow int) {
@@ -307,16 +543,88 @@ func clampTermSize(termWidth, termHeight int) (renderWidth, renderHeight, offset
 
 // updateStartState handles the start screen.
 func (c *Client) updateStartState() {
-	if c.state.ChatOpen {
-		return // Chat consumes input; don't trigger game actions
+	if c.inputCaptured() {
+		return // Chat or settings consume input; don't trigger game actions
 	}
 	if c.state.Input.Space || c.state.Input.Enter {
 		c.startGame()
+		return
+	}
+	if c.state.Input.Escape {
+		c.requestQuit()
+		return
+	}
+
+	// A/D (or arrows) cycle the ship silhouette; edge-triggered so a held
+	// key changes the selection once
+	n := object.ShipShape(object.ShipShapeCount)
+	if c.state.Input.Left && !c.state.prevInput.Left {
+		c.state.Ship = (c.state.Ship + n - 1) % n
+	}
+	if c.state.Input.Right && !c.state.prevInput.Right {
+		c.state.Ship = (c.state.Ship + 1) % n
+	}
+}
+
+// updateNameEntryState handles the name entry screen. Enter confirms the
+// name (sanitized as SSH usernames are) and moves on to the title screen;
+// Escape quits.
+func (c *Client) updateNameEntryState() {
+	if c.state.Input.Escape {
+		c.requestQuit()
+		return
+	}
+	// Keys typed in the same frame as Enter belong to the name
+	c.state.NameInput = c.editText(c.state.NameInput, config.MaxUsernameLength)
+	if c.state.Input.Enter {
+		name := server.SanitizeUsername(c.state.NameInput)
+		if name == "" {
+			return
+		}
+		c.server.SetUsername(c.handle.ID, name)
+		c.username = name
+		c.state.PersonalBest = c.handle.PersonalBest
+		c.state.NameInput = ""
+		c.state.GameState = GameStateStart
+		input.ResetKeyInput(c.inputStream)
+		c.state.Input.Enter = false // Prevent same-frame start
 	}
 }
 
+// editText applies this frame's typing to text: Backspace or Delete removes
+// the last rune, and printable keys are appended up to limit runes.
+func (c *Client) editText(text string, limit int) string {
+	if c.state.Input.Backspace || c.state.Input.Delete {
+		runes := []rune(text)
+		if len(runes) > 0 {
+			text = string(runes[:len(runes)-1])
+		}
+		return text
+	}
+	// Append printable runes from Pressed
+	printable := extractPrintableRunes(c.state.Input.Pressed)
+	if len(printable) == 0 {
+		return text
+	}
+	var b strings.Builder
+	b.WriteString(text)
+	runeCount := utf8.RuneCountInString(text)
+	for _, r := range printable {
+		if runeCount >= limit {
+			break
+		}
+		b.WriteRune(r)
+		runeCount++
+	}
+	return b.String()
+}
+
 // updatePlayingState handles the playing state.
 func (c *Client) updatePlayingState() {
+	if c.state.Paused {
+		return // Client-side timers freeze with the world
+	}
+
 	// Decrement invincibility timer
 	if c.state.InvincibleTime > 0 {
 		c.state.InvincibleTime -= c.state.delta.Seconds()
@@ -325,19 +633,85 @@ func (c *Client) updatePlayingState() {
 		}
 	}
 
+	// Death cam: the camera stays where the ship exploded until it runs out
+	if c.state.deathCamTime > 0 {
+		dt := c.state.delta.Seconds()
+		c.state.deathCamTime -= dt
+		c.state.RespawnTimeRemaining = max(c.state.RespawnTimeRemaining-dt, 0)
+		if c.state.deathCamTime <= 0 {
+			c.state.deathCamTime = 0
+			if !c.killCam.start() {
+				c.state.GameState = GameStateDead
+			}
+		}
+		return
+	}
+
+	if c.killCam.replaying() {
+		c.updateKillCam()
+		return
+	}
+
 	// Update camera to follow player
-	c.state.Player = c.server.GetClientPlayer(c.handle.ID)
+	c.state.Player = c.snapshot.Ship(c.handle.ID)
 	if c.state.Player != nil {
 		px, py := c.state.Player.GetPosition()
-		c.state.Camera.X = px
-		c.state.Camera.Y = py
+		world := c.snapshot.World
+		wrapW, wrapH := float64(world.Width), float64(world.Height)
+		if c.snapshot.Bounded {
+			wrapW, wrapH = 0, 0 // The ship never jumps across an edge
+		}
+		c.state.Camera.X = followAxis(c.state.Camera.X, px, c.deadzone.X, wrapW)
+		c.state.Camera.Y = followAxis(c.state.Camera.Y, py, c.deadzone.Y, wrapH)
+		if !c.snapshot.Bounded {
+			world.WrapPosition(&c.state.Camera.X, &c.state.Camera.Y)
+		}
+		c.killCam.record(c.snapshot, c.state.delta.Seconds())
 	}
 }
 
+// updateKillCam plays the kill cam replay in place of the live world: the
+// frame's snapshot is drawn with the camera on the player's ship and the
+// killer highlighted. Any key skips to the dead screen.
+func (c *Client) updateKillCam() {
+	dt := c.state.delta.Seconds()
+	c.state.RespawnTimeRemaining = max(c.state.RespawnTimeRemaining-dt, 0)
+	if len(c.state.Input.Pressed) > 0 && !c.inputCaptured() && c.killCam.canSkip() {
+		c.killCam.stop()
+	}
+	snap := c.killCam.step(dt)
+	if !c.killCam.replaying() {
+		c.killCamTarget = nil
+		input.ResetKeyInput(c.inputStream)
+		c.state.GameState = GameStateDead
+		return
+	}
+	c.snapshot = snap
+	own := snap.Ship(c.handle.ID)
+	if own != nil {
+		c.state.Camera.X, c.state.Camera.Y = own.X, own.Y
+	}
+	c.killCamTarget = killer(snap, own, c.state.KilledBy)
+}
+
+// followAxis moves a camera coordinate just enough to keep target within
+// deadzone of it, taking the short way around a wrapping world of the given
+// size (0 for a bounded world). A zero deadzone locks the camera on the target.
+func followAxis(cam, target, deadzone, size float64) float64 {
+	d := wrapDelta(target-cam, size)
+	switch {
+	case d > deadzone:
+		return cam + d - deadzone
+	case d < -deadzone:
+		return cam + d + deadzone
+	}
+	return cam
+}
+
 // updateDeadState handles the death screen.
 func (c *Client) updateDeadState() {
-	if c.state.ChatOpen {
-		// Chat consumes input; only update respawn timer
+	if c.inputCaptured() {
+		// Chat or settings consume input; only update respawn timer
 		if c.state.RespawnTimeRemaining > 0 {
 			c.state.RespawnTimeRemaining -= c.state.delta.Seconds()
 			if c.state.RespawnTimeRemaining < 0 {
@@ -362,6 +736,15 @@ func (c *Client) updateDeadState() {
 	}
 }
 
+// recordGameOver compares the final score with the personal best, so the
+// game-over screen can show the best or celebrate a new one.
+func (c *Client) recordGameOver() {
+	c.state.NewPersonalBest = c.state.Score > c.state.PersonalBest
+	if c.state.NewPersonalBest {
+		c.state.PersonalBest = c.state.Score
+	}
+}
+
 // startGame starts or restarts the game.
 func (c *Client) startGame() {
 	input.ResetKeyInput(c.inputStream)
@@ -373,9 +756,9 @@ func (c *Client) startGame() {
 		c.server.ResetScore(c.handle.ID)
 	}
 
-	// Request server to spawn player
-	c.server.SpawnPlayer(c.handle.ID)
-	c.state.Player = c.server.GetClientPlayer(c.handle.ID)
+	// Request server to spawn player with the selected silhouette
+	c.server.SetShipShape(c.handle.ID, c.state.Ship)
+	c.state.Player = c.server.SpawnPlayerIn(c.handle.ID, c.spawnRegion)
 
 	// Reset camera to player position
 	if c.state.Player != nil {
@@ -390,6 +773,42 @@ func (c *Client) startGame() {
 	c.state.GameState = GameStatePlaying
 }
 
+// requestQuit handles a quit key press. Players who have played this session
+// see a summary first; a second quit (or quitting from the summary, the
+// shutdown screen, or before ever playing) ends the session immediately.
+func (c *Client) requestQuit() {
+	switch c.state.GameState {
+	case GameStateSummary, GameStateShutdown:
+		c.state.Running = false
+		return
+	}
+	summary := c.server.GetSessionStats(c.handle.ID)
+	if summary.TimePlayed <= 0 {
+		c.state.Running = false
+		return
+	}
+	input.ResetKeyInput(c.inputStream)
+	c.state.Summary = summary
+	c.state.summaryReturnState = c.state.GameState
+	c.state.GameState = GameStateSummary
+}
+
+// updateSummaryState handles the session summary screen. Enter/Space confirm
+// quitting; Escape returns to the screen the player quit from.
+func (c *Client) updateSummaryState() {
+	if c.inputCaptured() {
+		return // Chat or settings consume input; don't trigger game actions
+	}
+	if c.state.Input.Space || c.state.Input.Enter {
+		c.state.Running = false
+		return
+	}
+	if c.state.Input.Escape {
+		input.ResetKeyInput(c.inputStream)
+		c.state.GameState = c.state.summaryReturnState
+	}
+}
+
 // updateShutdownState handles the shutdown screen countdown.
 func (c *Client) updateShutdownState() {
 	c.state.shutdownTimer -= c.state.delta.Seconds()
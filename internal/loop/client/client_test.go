@@ -0,0 +1,245 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tomz197/asteroids/internal/clock"
+	"github.com/tomz197/asteroids/internal/draw"
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/loop/server"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// closableWriter discards output until closed, then fails every write the
+// way a dropped SSH connection does.
+type closableWriter struct {
+	mu     sync.Mutex
+	closed bool
+}
+
+func (w *closableWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return len(p), nil
+}
+
+func (w *closableWriter) close() {
+	w.mu.Lock()
+	w.closed = true
+	w.mu.Unlock()
+}
+
+// TestClientsAgainstRunningServer plays several in-process clients against a
+// real server while it updates the world. Run with -race: clients draw from
+// snapshots while the server moves, pools and replaces objects, so any
+// shared mutable state between the two shows up as a race.
+func TestClientsAgainstRunningServer(t *testing.T) {
+	const (
+		clients  = 4
+		playTime = 2 * time.Second
+	)
+	// Start, then fly, turn, shoot, toggle the leaderboard, chat, and
+	// respawn with Enter whenever the ship is destroyed.
+	script := []string{" ", "w", "a", " ", "d", "w", " ", "\t", "s", "\t", "c", "hi\r", "\r", "ww", "  "}
+
+	s, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	var wg sync.WaitGroup
+	for i := range clients {
+		in, keys := io.Pipe()
+		out := &closableWriter{}
+		c := NewClient(s, bufio.NewReader(in), out, ClientOptions{
+			TermSizeFunc: func() (int, int, error) { return 120, 40, nil },
+			Username:     fmt.Sprintf("pilot%d", i),
+			Ship:         object.ShipShape(i % 3),
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.Run(); err != nil {
+				t.Errorf("client %d: %v", i, err)
+			}
+		}()
+
+		// Type the script on a loop until play time is up, then drop the
+		// connection.
+		go func() {
+			defer keys.Close()
+			stop := time.After(playTime)
+			tick := time.NewTicker(20 * time.Millisecond)
+			defer tick.Stop()
+			for n := 0; ; n++ {
+				select {
+				case <-stop:
+					out.close()
+					return
+				case <-tick.C:
+					if _, err := io.WriteString(keys, script[(n+i)%len(script)]); err != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	// Make sure the clients are really playing, not idling on a menu.
+	time.Sleep(playTime / 2)
+	if ships := len(s.GetSnapshot().UserObjects); ships == 0 {
+		t.Errorf("no ships in play halfway through")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(playTime + 5*time.Second):
+		t.Fatal("clients did not exit after their connections closed")
+	}
+	if n := s.Stats().Players; n != 0 {
+		// Unregistrations are applied on the next tick.
+		time.Sleep(100 * time.Millisecond)
+		if n = s.Stats().Players; n != 0 {
+			t.Errorf("%d players still connected, want 0", n)
+		}
+	}
+}
+
+// TestRunRestoresTerminal ends a session on each way Run can exit and checks
+// the terminal is left usable: the output ends with colors reset, the cursor
+// shown and the screen cleared.
+func TestRunRestoresTerminal(t *testing.T) {
+	var reset bytes.Buffer
+	draw.ResetTerminal(&reset)
+
+	tests := []struct {
+		name string
+		end  func(s *server.Server, c *Client, keys io.Writer, clk *clock.Fake, cancel context.CancelFunc)
+	}{
+		{"quit", func(_ *server.Server, _ *Client, keys io.Writer, _ *clock.Fake, _ context.CancelFunc) {
+			io.WriteString(keys, "q")
+		}},
+		{"disconnect", func(s *server.Server, c *Client, _ io.Writer, _ *clock.Fake, _ context.CancelFunc) {
+			// The server drops the client and closes its event channel
+			s.UnregisterClient(c.handle.ID)
+		}},
+		{"context cancel", func(s *server.Server, _ *Client, _ io.Writer, clk *clock.Fake, cancel context.CancelFunc) {
+			// Shut the server down and cancel its context, then let the
+			// shutdown screen count down
+			s.Shutdown(0)
+			cancel()
+			for range 2 * config.ShutdownDisplayTime / time.Second {
+				clk.Advance(time.Second)
+				time.Sleep(10 * time.Millisecond)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SCORES_FILE", "")
+			s, err := server.NewServer()
+			if err != nil {
+				t.Fatalf("NewServer: %v", err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go s.Run(ctx)
+
+			in, keys := io.Pipe()
+			defer keys.Close()
+			var out bytes.Buffer // Only read once Run has returned and flushed
+			clk := clock.NewFake(time.Unix(0, 0))
+			c := NewClient(s, bufio.NewReader(in), &out, ClientOptions{
+				TermSizeFunc: func() (int, int, error) { return 80, 24, nil },
+				Username:     "pilot",
+				ColorLevel:   draw.ColorLevel256,
+				Clock:        clk,
+			})
+
+			done := make(chan error, 1)
+			go func() { done <- c.Run() }()
+			time.Sleep(50 * time.Millisecond) // A few frames on the title screen
+			go tt.end(s, c, keys, clk, cancel)
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("Run: %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Run did not exit")
+			}
+			got := out.Bytes()
+			if !bytes.HasSuffix(got, reset.Bytes()) {
+				tail := got[max(len(got)-40, 0):]
+				t.Errorf("output ends with %q, want the terminal reset %q", tail, reset.Bytes())
+			}
+			if bytes.LastIndex(got, []byte("\033[?25l")) > bytes.LastIndex(got, []byte("\033[?25h")) {
+				t.Error("cursor left hidden")
+			}
+		})
+	}
+}
+
+// TestClientIgnoresZeroTermSize starts a client on a terminal reporting 0x0,
+// as some SSH clients do before the real size arrives: it must render at
+// the default size until then, take the real size, and keep it through any
+// later 0x0 report.
+func TestClientIgnoresZeroTermSize(t *testing.T) {
+	t.Setenv("SCORES_FILE", "")
+	s, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	var size [2]int // Terminal size reported to the client
+	c := NewClient(s, bufio.NewReader(strings.NewReader("")), io.Discard, ClientOptions{
+		TermSizeFunc: func() (int, int, error) { return size[0], size[1], nil },
+		Username:     "pilot",
+	})
+	defer c.writer.Close()
+
+	steps := []struct {
+		report [2]int
+		want   [2]int
+	}{
+		{[2]int{0, 0}, [2]int{config.DefaultTermWidth, config.DefaultTermHeight}},
+		{[2]int{100, 40}, [2]int{100, 40}},
+		{[2]int{0, 0}, [2]int{100, 40}},
+		{[2]int{100, 0}, [2]int{100, 40}},
+	}
+	for i, st := range steps {
+		size = st.report
+		if i > 0 {
+			c.updateScreen()
+		}
+		c.snapshot = s.GetSnapshotFor(c.handle.ID)
+		if err := c.drawFrame(); err != nil {
+			t.Fatalf("step %d: drawFrame: %v", i, err)
+		}
+		got := [2]int{c.canvas.TerminalWidth(), c.canvas.TerminalHeight()}
+		if got != st.want {
+			t.Errorf("step %d: reported %dx%d, rendered at %dx%d, want %dx%d",
+				i, st.report[0], st.report[1], got[0], got[1], st.want[0], st.want[1])
+		}
+	}
+}
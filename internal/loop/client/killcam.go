@@ -0,0 +1,137 @@
+package client
+
+import (
+	"math"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/loop/server"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+const (
+	// killCamSampleRate is how many snapshots per second the kill cam keeps.
+	// Snapshots are shared between clients, so holding a few dozen only
+	// delays their collection.
+	killCamSampleRate = 20.0
+
+	// killCamSkipDelay ignores keys for the start of a replay, so keys still
+	// held from playing don't skip it at once.
+	killCamSkipDelay = 0.3
+)
+
+// killCam keeps a rolling history of world snapshots while the player is
+// alive, and replays the last config.KillCamTime of it in slow motion after
+// a death.
+type killCam struct {
+	frames  []*server.WorldSnapshot // Ring buffer of recorded snapshots
+	next    int                     // Ring index written next
+	count   int                     // Recorded snapshots in the ring
+	elapsed float64                 // Seconds since the last recorded snapshot
+
+	replay     []*server.WorldSnapshot // Frames being replayed, oldest first; nil when not replaying
+	replayTime float64                 // Replay position in recorded seconds
+	shown      float64                 // Real seconds the replay has been on screen
+}
+
+// newKillCam creates a kill cam sized for config.KillCamTime. A zero
+// KillCamTime disables recording.
+func newKillCam() *killCam {
+	n := int(math.Ceil(config.KillCamTime.Seconds() * killCamSampleRate))
+	return &killCam{frames: make([]*server.WorldSnapshot, n)}
+}
+
+// record adds snap to the history at most killCamSampleRate times a second.
+func (k *killCam) record(snap *server.WorldSnapshot, dt float64) {
+	if len(k.frames) == 0 {
+		return
+	}
+	k.elapsed += dt
+	if k.count > 0 && k.elapsed < 1/killCamSampleRate {
+		return
+	}
+	k.elapsed = 0
+	k.frames[k.next] = snap
+	k.next = (k.next + 1) % len(k.frames)
+	k.count = min(k.count+1, len(k.frames))
+}
+
+// start begins replaying the recorded history and clears it for the next
+// life. Returns false if there is nothing to replay.
+func (k *killCam) start() bool {
+	if k.count < 2 {
+		k.reset()
+		return false
+	}
+	k.replay = make([]*server.WorldSnapshot, 0, k.count)
+	for i := 0; i < k.count; i++ {
+		k.replay = append(k.replay, k.frames[(k.next-k.count+i+len(k.frames))%len(k.frames)])
+	}
+	k.replayTime = 0
+	k.shown = 0
+	k.reset()
+	return true
+}
+
+// reset drops the recorded history, releasing its snapshots.
+func (k *killCam) reset() {
+	clear(k.frames)
+	k.next, k.count, k.elapsed = 0, 0, 0
+}
+
+// replaying reports whether a replay is in progress.
+func (k *killCam) replaying() bool {
+	return k.replay != nil
+}
+
+// step advances the replay by dt real seconds at config.KillCamSpeed and
+// returns the snapshot to show, or nil once the replay has finished.
+func (k *killCam) step(dt float64) *server.WorldSnapshot {
+	k.shown += dt
+	k.replayTime += dt * config.KillCamSpeed
+	i := int(k.replayTime * killCamSampleRate)
+	if i >= len(k.replay) {
+		k.stop()
+		return nil
+	}
+	return k.replay[i]
+}
+
+// stop ends the replay.
+func (k *killCam) stop() {
+	k.replay = nil
+}
+
+// canSkip reports whether a keypress may skip the replay yet.
+func (k *killCam) canSkip() bool {
+	return k.shown >= killCamSkipDelay
+}
+
+// killer returns the object to highlight in a replay frame: the ship of the
+// player named killedBy, or, for an asteroid death, the asteroid nearest the
+// player's ship.
+func killer(snap *server.WorldSnapshot, own *object.User, killedBy string) object.Object {
+	if killedBy != "" {
+		for _, u := range snap.UserObjects {
+			if u.Username == killedBy && u != own {
+				return u
+			}
+		}
+		return nil
+	}
+	if own == nil {
+		return nil
+	}
+	var nearest object.Object
+	best := math.Inf(1)
+	for _, obj := range snap.Objects {
+		a, ok := obj.(*object.Asteroid)
+		if !ok {
+			continue
+		}
+		dx, dy := worldDelta(snap, a.X-own.X, a.Y-own.Y)
+		if d := dx*dx + dy*dy; d < best {
+			best, nearest = d, a
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tomz197/asteroids/internal/draw"
+)
+
+// MinimapCorner selects the screen corner the minimap sits in.
+type MinimapCorner int
+
+const (
+	MinimapTopRight    MinimapCorner = iota // Below the lives counter (default)
+	MinimapTopLeft                          // Below the score; the leaderboard moves under it
+	MinimapBottomRight                      // Above the player count
+	MinimapBottomLeft                       // Bottom left; chat history draws over it
+)
+
+// minimapCornerNames holds the name of each MinimapCorner, indexed by corner.
+var minimapCornerNames = [...]string{
+	MinimapTopRight:    "top-right",
+	MinimapTopLeft:     "top-left",
+	MinimapBottomRight: "bottom-right",
+	MinimapBottomLeft:  "bottom-left",
+}
+
+// String returns the corner's name.
+func (c MinimapCorner) String() string {
+	if c < 0 || int(c) >= len(minimapCornerNames) {
+		return minimapCornerNames[MinimapTopRight]
+	}
+	return minimapCornerNames[c]
+}
+
+// MinimapCornerByName returns the corner with the given name, or
+// MinimapTopRight and false if none matches.
+func MinimapCornerByName(name string) (MinimapCorner, bool) {
+	for i, n := range minimapCornerNames {
+		if n == name {
+			return MinimapCorner(i), true
+		}
+	}
+	return MinimapTopRight, false
+}
+
+// Minimap inner grid size limits (terminal columns and rows, excluding the
+// border). The grid has 2 sub-rows per terminal row for half-block resolution.
+const (
+	defaultMinimapWidth  = 20
+	defaultMinimapHeight = 10
+	minMinimapWidth      = 8
+	maxMinimapWidth      = 60
+	minMinimapHeight     = 4
+	maxMinimapHeight     = 30
+)
+
+// minimapExtrasWidth is the widest line drawn next to the minimap
+// (coordinates and heading), in columns.
+const minimapExtrasWidth = 18
+
+// MinimapOptions sizes and places the minimap. Zero sizes select the default
+// 20x10; others are clamped to 8-60 columns and 4-30 rows.
+type MinimapOptions struct {
+	Width  int // Inner grid columns
+	Height int // Inner grid terminal rows
+	Corner MinimapCorner
+}
+
+// ParseMinimapOptions parses a size such as "30x15" ("" keeps the default)
+// and a corner name ("" keeps top-right).
+func ParseMinimapOptions(size, corner string) (MinimapOptions, error) {
+	var opts MinimapOptions
+	if size != "" {
+		if _, err := fmt.Sscanf(size, "%dx%d", &opts.Width, &opts.Height); err != nil || opts.Width <= 0 || opts.Height <= 0 {
+			return MinimapOptions{}, fmt.Errorf("minimap size must look like 20x10 (got %q)", size)
+		}
+	}
+	if corner != "" {
+		c, ok := MinimapCornerByName(corner)
+		if !ok {
+			return MinimapOptions{}, fmt.Errorf("unknown minimap corner %q (want %s)", corner, strings.Join(minimapCornerNames[:], ", "))
+		}
+		opts.Corner = c
+	}
+	return opts, nil
+}
+
+// minimap is a client's minimap layout and its reusable buffers.
+type minimap struct {
+	width, height int
+	corner        MinimapCorner
+	box           draw.Box // Frame characters topBorder and bottomBorder were built with
+	topBorder     string   // Pre-computed border strings (avoid per-frame strings.Repeat)
+	bottomBorder  string
+	grid          []byte // 2*height sub-rows of width cells: 0=empty, 1=other, 2=self
+}
+
+// newMinimap builds the layout for opts, applying defaults and limits.
+func newMinimap(opts MinimapOptions) *minimap {
+	w, h := opts.Width, opts.Height
+	if w == 0 {
+		w = defaultMinimapWidth
+	}
+	if h == 0 {
+		h = defaultMinimapHeight
+	}
+	w = min(max(w, minMinimapWidth), maxMinimapWidth)
+	h = min(max(h, minMinimapHeight), maxMinimapHeight)
+	m := &minimap{
+		width:  w,
+		height: h,
+		corner: opts.Corner,
+		grid:   make([]byte, w*2*h),
+	}
+	m.setBox(draw.BoxLight)
+	return m
+}
+
+// setBox rebuilds the border strings when the frame characters change
+// (ASCII mode toggled).
+func (m *minimap) setBox(box draw.Box) {
+	if m.topBorder != "" && m.box == box {
+		return
+	}
+	m.box = box
+	m.topBorder = box.TopLeft + strings.Repeat(box.Horizontal, m.width) + box.TopRight
+	m.bottomBorder = box.BottomLeft + strings.Repeat(box.Horizontal, m.width) + box.BottomRight
+}
+
+// subRows returns the number of grid sub-rows (2 per terminal row).
+func (m *minimap) subRows() int {
+	return 2 * m.height
+}
+
+// top reports whether the minimap sits in a top corner.
+func (m *minimap) top() bool {
+	return m.corner == MinimapTopRight || m.corner == MinimapTopLeft
+}
+
+// origin returns the 1-based terminal position of the minimap's top-left
+// border corner, and whether the whole box fits in the terminal.
+func (m *minimap) origin(termWidth, termHeight int) (col, row int, ok bool) {
+	col = termWidth - m.width - 3 // border + padding
+	if m.corner == MinimapTopLeft || m.corner == MinimapBottomLeft {
+		col = 2
+	}
+	row = 3 // Below the score and lives
+	if !m.top() {
+		row = termHeight - m.height - 2 // Bottom border just above the last row
+	}
+	ok = col >= 1 && row >= 2 && col+m.width+1 <= termWidth && row+m.height+1 <= termHeight
+	return col, row, ok
+}
+
+// extrasRow returns the terminal row of the first line drawn with the
+// minimap (coordinates, then heading): below it in top corners, above it in
+// bottom ones.
+func (m *minimap) extrasRow(row int) int {
+	if m.top() {
+		return row + m.height + 2
+	}
+	return row - 2
+}
+
+// extrasCol returns the column of the lines drawn with the minimap, kept far
+// enough from the right edge that they never wrap.
+func (m *minimap) extrasCol(col, termWidth int) int {
+	return max(min(col, termWidth-minimapExtrasWidth), 1)
+}
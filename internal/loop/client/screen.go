@@ -1,6 +1,8 @@
 package client
 
 import (
+	"log"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -19,39 +21,109 @@ func (c *Client) drawFrame() error {
 	stateChanged := c.state.GameState != c.state.prevGameState
 	inactiveChanged := c.state.isInactive != c.state.wasInactive
 	chatOpenChanged := c.state.ChatOpen != c.state.prevChatOpen
-	if stateChanged || inactiveChanged || chatOpenChanged {
+	settingsChanged := c.state.SettingsOpen != c.state.prevSettingsOpen
+	helpChanged := c.state.HelpOpen != c.state.prevHelpOpen
+	// Output discarded under backpressure leaves the terminal out of sync
+	// with the canvas diff state
+	outputDropped := c.writer.Dropped()
+	if stateChanged || inactiveChanged || chatOpenChanged || settingsChanged || helpChanged || outputDropped {
 		c.chunkWriter.WriteString("\033[H\033[2J")
 		c.canvas.ForceRedraw()
 		c.state.prevGameState = c.state.GameState
 		c.state.wasInactive = c.state.isInactive
 		c.state.prevChatOpen = c.state.ChatOpen
+		c.state.prevSettingsOpen = c.state.SettingsOpen
+		c.state.prevHelpOpen = c.state.HelpOpen
+	}
+	c.canvas.SetDimmed(c.state.HelpOpen)
+
+	// Cursor visibility: show when chat is open for typing
+	if c.state.ChatOpen {
+		draw.ShowCursor(c.chunkWriter)
+	} else {
+		draw.HideCursor(c.chunkWriter)
 	}
 
 	c.canvas.Clear()
 
-	// Get world snapshot
-	snapshot := c.server.GetSnapshot()
+	// World snapshot taken for this frame (see Run)
+	snapshot := c.snapshot
 
 	// Create draw context
 	ctx := object.DrawContext{
-		Canvas: c.canvas,
-		Writer: c.chunkWriter,
-		Camera: c.state.Camera,
-		View:   c.state.View,
-		World:  snapshot.World,
+		Canvas:  c.canvas,
+		Writer:  c.chunkWriter,
+		Camera:  c.state.Camera,
+		View:    c.state.View,
+		World:   snapshot.World,
+		Bounded: snapshot.Bounded,
+
+		FilledAsteroids: c.state.FilledAsteroids,
+		ParticleStyle:   c.particleStyle,
+		ProjectileStyle: c.bulletStyle,
+		ProtectedColor:  c.colors.protected,
+		ExpiringColor:   c.expiringColor(),
+		ParticleHeat:    c.colorLevel >= draw.ColorLevel256,
+		ShipColors:      c.colors.ships,
 	}
-
-	// Draw all objects from snapshot
+	// Ships drawn under a frame-wide accent (kill cam, depth dimming, the
+	// invincibility fade) must not switch to their own color
+	plainCtx := ctx
+	plainCtx.ShipColors = nil
+	plainCtx.ParticleHeat = false
+	ownCtx := ctx
+	ownCtx.ShipColors = c.colors.selfShip
+
+	// Draw all objects from snapshot. A failing object is skipped rather than
+	// aborting the frame, so one bad object can't blank everyone's screen.
 	for _, obj := range snapshot.Objects {
-		// Skip drawing player when blinking (invincible)
-		if obj == c.state.Player && !object.ShouldRenderBlink(c.state.InvincibleTime, config.PlayerBlinkFrequency) {
+		if obj == c.killCamTarget && c.killCam.replaying() {
+			// Kill cam: point out what killed the player
+			c.canvas.SetAccent(c.colors.warning)
+			if err := obj.Draw(plainCtx); err != nil {
+				c.logDrawError(obj, err)
+			}
+			c.canvas.SetAccent("")
+			continue
+		}
+		if u, ok := obj.(*object.User); ok && !c.isOwnShip(u) {
+			// Ships far out toward the view edges recede (cosmetic only)
+			if dim := c.distantShipColor(u, snapshot); dim != "" {
+				c.canvas.SetAccent(dim)
+				if err := obj.Draw(plainCtx); err != nil {
+					c.logDrawError(obj, err)
+				}
+				c.canvas.SetAccent("")
+				continue
+			}
+		}
+		if u, ok := obj.(*object.User); ok && c.isOwnShip(u) {
+			// Fade out of invincibility, or skip drawing when blinking
+			if fade := c.invincibilityFade(); fade != "" {
+				c.canvas.SetAccent(fade)
+				if err := obj.Draw(plainCtx); err != nil {
+					c.logDrawError(obj, err)
+				}
+				c.canvas.SetAccent("")
+				continue
+			}
+			if !object.ShouldRenderBlink(c.state.InvincibleTime, config.PlayerBlinkFrequency) {
+				continue
+			}
+			if err := obj.Draw(ownCtx); err != nil {
+				c.logDrawError(obj, err)
+			}
 			continue
 		}
 		if err := obj.Draw(ctx); err != nil {
-			return err
+			c.logDrawError(obj, err)
 		}
 	}
 
+	// Aim reticle ahead of this client's ship
+	c.drawReticle(ctx)
+	c.drawEdgeWarning(snapshot)
+
 	// Render canvas to terminal
 	c.canvas.Render(c.chunkWriter)
 
@@ -59,7 +131,7 @@ func (c *Client) drawFrame() error {
 	c.canvas.RenderBorder(c.chunkWriter)
 
 	// Draw usernames above other players' ships
-	c.drawPlayerNames(snapshot.UserObjects, snapshot.World)
+	c.drawPlayerNames(snapshot.UserObjects, ctx)
 
 	// Draw UI overlay
 	c.drawUI(snapshot)
@@ -67,9 +139,26 @@ func (c *Client) drawFrame() error {
 	// Draw chat (overlays all screens)
 	c.drawChat(snapshot)
 
+	c.server.AddRenderBytes(c.chunkWriter.Len())
 	return c.chunkWriter.Flush()
 }
 
+// drawErrorLogInterval rate-limits object draw error logs per client, since a
+// persistently failing object would otherwise log every frame.
+const drawErrorLogInterval = 10 * time.Second
+
+// logDrawError logs an object draw failure, at most once per drawErrorLogInterval.
+func (c *Client) logDrawError(obj object.Object, err error) {
+	c.drawErrors++
+	now := c.clock.Now()
+	if now.Sub(c.lastDrawErrLog) < drawErrorLogInterval {
+		return
+	}
+	log.Printf("Draw error for client %d (%T, %d since last report): %v", c.handle.ID, obj, c.drawErrors, err)
+	c.lastDrawErrLog = now
+	c.drawErrors = 0
+}
+
 // drawUI draws the game UI overlay.
 func (c *Client) drawUI(snapshot *server.WorldSnapshot) {
 	termWidth := c.canvas.TerminalWidth()
@@ -90,11 +179,161 @@ func (c *Client) drawUI(snapshot *server.WorldSnapshot) {
 	switch c.state.GameState {
 	case GameStatePlaying:
 		c.drawPlayingHUD(termWidth, termHeight, snapshot)
+		c.drawKillCamLabel(centerX)
+		c.drawWaveBanner(centerX, centerY)
 	case GameStateStart:
 		c.drawStartScreen(centerX, centerY, snapshot)
+	case GameStateNameEntry:
+		c.drawNameEntryScreen(centerX, centerY)
 	case GameStateDead:
 		c.drawDeadScreen(centerX, centerY)
+	case GameStateSummary:
+		c.drawSummaryScreen(centerX, centerY)
+	}
+
+	if c.state.SettingsOpen {
+		c.drawSettings(centerX, centerY)
+	}
+	if c.state.HelpOpen {
+		c.drawHelp(centerX, centerY)
+	}
+
+	c.drawToast(centerX)
+}
+
+// drawReticle draws a faint dotted aim line ahead of the local ship along
+// its heading, when enabled in settings. Sparse dots keep it from being
+// mistaken for a ship or projectile.
+func (c *Client) drawReticle(ctx object.DrawContext) {
+	p := c.state.Player
+	if !c.state.Reticle || p == nil || c.state.GameState != GameStatePlaying {
+		return
+	}
+	sin, cos := math.Sincos(p.Angle)
+	positions := ctx.ToScreen(p.X, p.Y)
+	for i := 0; i < positions.Count; i++ {
+		pos := positions.Positions[i]
+		for j := 0; j < config.ReticleDotCount; j++ {
+			d := config.ReticleStart + float64(j)*config.ReticleSpacing
+			c.canvas.SetFloat(pos.X+cos*d, pos.Y+sin*d)
+		}
+	}
+}
+
+// drawEdgeWarning draws a dashed stripe along each world edge within
+// config.EdgeWarningMargin of the player. Only in bounded worlds, where the
+// edges are walls; wrapping worlds have none.
+func (c *Client) drawEdgeWarning(snapshot *server.WorldSnapshot) {
+	p := c.state.Player
+	if !snapshot.Bounded || p == nil || c.state.GameState != GameStatePlaying {
+		return
 	}
+	stripes := edgeStripes(p.X, p.Y, c.state.Camera, c.state.View, snapshot.World, config.EdgeWarningMargin)
+	if len(stripes) == 0 {
+		return
+	}
+	c.canvas.SetAccent(c.colors.warning)
+	for _, s := range stripes {
+		c.drawDashes(s[0], s[1])
+	}
+	c.canvas.SetAccent("")
+}
+
+// edgeStripes returns the view-space lines to mark for each world edge
+// within margin of (px, py): along the edge itself where it is in view,
+// otherwise along the side of the view facing it.
+func edgeStripes(px, py float64, cam object.Camera, view, world object.Screen, margin float64) [][2]draw.Point {
+	if margin <= 0 {
+		return nil
+	}
+	// Stay half a unit inside the view so the stripe isn't rounded off it
+	minX, minY := 0.5, 0.5
+	maxX, maxY := float64(view.Width)-0.5, float64(view.Height)-0.5
+	camLeft := cam.X - float64(view.Width)/2
+	camTop := cam.Y - float64(view.Height)/2
+	clampX := func(x float64) float64 { return max(minX, min(x, maxX)) }
+	clampY := func(y float64) float64 { return max(minY, min(y, maxY)) }
+
+	var stripes [][2]draw.Point
+	if px < margin {
+		x := clampX(-camLeft)
+		stripes = append(stripes, [2]draw.Point{{X: x, Y: minY}, {X: x, Y: maxY}})
+	}
+	if float64(world.Width)-px < margin {
+		x := clampX(float64(world.Width) - camLeft)
+		stripes = append(stripes, [2]draw.Point{{X: x, Y: minY}, {X: x, Y: maxY}})
+	}
+	if py < margin {
+		y := clampY(-camTop)
+		stripes = append(stripes, [2]draw.Point{{X: minX, Y: y}, {X: maxX, Y: y}})
+	}
+	if float64(world.Height)-py < margin {
+		y := clampY(float64(world.Height) - camTop)
+		stripes = append(stripes, [2]draw.Point{{X: minX, Y: y}, {X: maxX, Y: y}})
+	}
+	return stripes
+}
+
+// drawDashes draws a dashed line from a to b, alternating
+// config.EdgeWarningDash of line and gap.
+func (c *Client) drawDashes(a, b draw.Point) {
+	length := math.Hypot(b.X-a.X, b.Y-a.Y)
+	if length == 0 {
+		return
+	}
+	ux, uy := (b.X-a.X)/length, (b.Y-a.Y)/length
+	for d := 0.0; d < length; d += 2 * config.EdgeWarningDash {
+		end := min(d+config.EdgeWarningDash, length)
+		c.canvas.DrawLine(
+			draw.Point{X: a.X + ux*d, Y: a.Y + uy*d},
+			draw.Point{X: a.X + ux*end, Y: a.Y + uy*end},
+		)
+	}
+}
+
+// drawKillCamLabel marks the kill cam replay on the third row.
+func (c *Client) drawKillCamLabel(centerX int) {
+	if !c.killCam.replaying() {
+		return
+	}
+	text := "KILL CAM - any key skips"
+	width := utf8.RuneCountInString(text)
+	col := max(centerX-width/2, 1)
+	c.chunkWriter.WriteColoredAt(col, 3, c.colors.warning, text)
+	c.canvas.MarkTextDirty(col, 3, width)
+}
+
+// waveBannerBlink is how fast the "WAVE N" banner flashes, in Hz.
+const waveBannerBlink = 4.0
+
+// drawWaveBanner flashes "WAVE N" above the ship while a wave begins.
+// Marks its cells dirty so the canvas cleans it up between flashes.
+func (c *Client) drawWaveBanner(centerX, centerY int) {
+	if c.state.waveBanner == 0 || !object.ShouldRenderBlink(c.state.waveBannerTime, waveBannerBlink) {
+		return
+	}
+	text := "W A V E   " + strconv.Itoa(c.state.waveBanner)
+	width := utf8.RuneCountInString(text)
+	col := max(centerX-width/2, 1)
+	row := max(centerY-6, 1)
+	c.chunkWriter.WriteColoredAt(col, row, c.colors.title, text)
+	c.canvas.MarkTextDirty(col, row, width)
+}
+
+// drawToast draws the active toast notification centered on the second row.
+// Marks its cells dirty so the canvas cleans it up once the toast expires.
+func (c *Client) drawToast(centerX int) {
+	if c.state.Toast == "" {
+		return
+	}
+	text := "* " + c.state.Toast + " *"
+	width := utf8.RuneCountInString(text)
+	col := centerX - width/2
+	if col < 1 {
+		col = 1
+	}
+	c.chunkWriter.WriteColoredAt(col, 2, c.colors.title, text)
+	c.canvas.MarkTextDirty(col, 2, width)
 }
 
 // chatHistoryLines is the number of chat history lines to display when chat is closed.
@@ -107,10 +346,11 @@ const chatHistoryLinesActive = 12
 const chatWidth = 40
 
 // drawChat draws the chat history and input box. Overlays all screens.
-// Uses a narrow column so asteroids remain visible. Pads lines to clear artefacts.
+// Uses a narrow column so asteroids remain visible, clipped to the terminal.
+// Pads lines to clear artefacts.
 func (c *Client) drawChat(snapshot *server.WorldSnapshot) {
+	termWidth := c.canvas.TerminalWidth()
 	termHeight := c.canvas.TerminalHeight()
-	cw := c.chunkWriter
 
 	messages := snapshot.ChatMessages
 	if messages == nil {
@@ -121,18 +361,14 @@ func (c *Client) drawChat(snapshot *server.WorldSnapshot) {
 	if c.state.ChatOpen {
 		msgRows = chatHistoryLinesActive
 	}
-	displayCount := msgRows
-	if len(messages) < displayCount {
-		displayCount = len(messages)
-	}
-	displayMessages := messages
-	if len(messages) > msgRows {
-		start := len(messages) - msgRows
-		if start < 0 {
-			start = 0
+	// Oldest message shown; while chat is closed, messages older than
+	// ChatFadeTime have faded out
+	first := max(len(messages)-msgRows, 0)
+	now := c.clock.Now()
+	if !c.state.ChatOpen {
+		for first < len(messages) && now.Sub(messages[first].At) >= config.ChatFadeTime {
+			first++
 		}
-		displayMessages = messages[start:]
-		displayCount = msgRows
 	}
 
 	// Layout: when chat open, input and hint use 2 rows at bottom
@@ -145,15 +381,26 @@ func (c *Client) drawChat(snapshot *server.WorldSnapshot) {
 		hintRow = termHeight
 	}
 
-	// Draw messages (wrap to multiple lines if needed, cached until message count changes)
-	if len(messages) != c.state.cachedChatMsgCount {
+	// Draw messages (wrap to multiple lines if needed, cached until the
+	// shown messages change)
+	var newest time.Time
+	if len(messages) > 0 {
+		newest = messages[len(messages)-1].At
+	}
+	if len(messages) != c.state.cachedChatMsgCount || first != c.state.cachedChatFirst || !newest.Equal(c.state.cachedChatNewest) {
 		c.state.cachedChatLines = c.state.cachedChatLines[:0]
-		for i := 0; i < displayCount; i++ {
-			m := displayMessages[i]
+		c.state.cachedChatLineMsg = c.state.cachedChatLineMsg[:0]
+		for i := first; i < len(messages); i++ {
+			m := messages[i]
 			fullLine := truncate(m.Username, 12) + ": " + m.Text
-			c.state.cachedChatLines = append(c.state.cachedChatLines, wrapText(fullLine, chatWidth)...)
+			for _, line := range wrapText(fullLine, chatWidth) {
+				c.state.cachedChatLines = append(c.state.cachedChatLines, line)
+				c.state.cachedChatLineMsg = append(c.state.cachedChatLineMsg, i)
+			}
 		}
 		c.state.cachedChatMsgCount = len(messages)
+		c.state.cachedChatFirst = first
+		c.state.cachedChatNewest = newest
 	}
 	allLines := c.state.cachedChatLines
 	// Take last N lines to fit in available rows (newest at bottom)
@@ -165,25 +412,31 @@ func (c *Client) drawChat(snapshot *server.WorldSnapshot) {
 		row := historyStart + i
 		if row >= 1 && row <= termHeight {
 			line := allLines[lineStart+i]
-			c.canvas.MarkTextDirty(2, row, len(line))
-			cw.WriteAt(2, row, line)
+			c.canvas.MarkTextDirty(2, row, textWidth(line))
+			// Dim messages about to fade out
+			m := messages[c.state.cachedChatLineMsg[lineStart+i]]
+			color := ""
+			if !c.state.ChatOpen && now.Sub(m.At) >= config.ChatFadeTime-config.ChatFadeOutTime {
+				color = draw.ColorDim
+			}
+			c.writeHUDTextColored(2, row, termWidth, termHeight, color, line)
 		}
 	}
 
 	if c.state.ChatOpen {
 		hint := "ESC to close, Enter to send"
-		cw.WriteAt(2, hintRow, hint)
+		c.writeHUDTextColored(2, hintRow, termWidth, termHeight, "", hint)
 
 		prompt := "> " + c.state.ChatInput
 		if utf8.RuneCountInString(prompt) > config.MaxChatMessageLength {
 			prompt = truncate(prompt, config.MaxChatMessageLength)
 		}
-		cw.WriteAt(2, inputRow, prompt)
+		c.writeHUDTextColored(2, inputRow, termWidth, termHeight, "", prompt)
 		c.canvas.MarkTextDirty(2, inputRow, chatWidth)
 		c.canvas.MarkTextDirty(2, hintRow, chatWidth)
 	} else {
-		hint := "Press C to start chatting"
-		cw.WriteAt(2, hintRow, hint)
+		hint := "Press C or T to start chatting"
+		c.writeHUDTextColored(2, hintRow, termWidth, termHeight, "", hint)
 	}
 }
 
@@ -191,17 +444,44 @@ func (c *Client) drawChat(snapshot *server.WorldSnapshot) {
 func (c *Client) drawInactivityScreen(centerX, centerY int) {
 	cw := c.chunkWriter
 	title := "INACTIVITY WARNING"
-	cw.WriteAt(centerX-len(title)/2, centerY-2, title)
+	cw.WriteColoredAt(centerX-textWidth(title)/2, centerY-2, c.colors.warning, title)
 
 	b := c.hudBuf[:0]
 	b = append(b, "You have been inactive for too long. You will be disconnected in "...)
-	b = strconv.AppendInt(b, int64(config.InactivityDisconnectUser-time.Since(c.lastInput).Seconds()), 10)
+	b = strconv.AppendInt(b, int64(config.InactivityDisconnectUser-c.since(c.lastInput).Seconds()), 10)
 	b = append(b, " seconds."...)
 	msg := string(b)
-	cw.WriteAt(centerX-len(msg)/2, centerY, msg)
+	cw.WriteColoredAt(centerX-textWidth(msg)/2, centerY, c.colors.hud, msg)
 
 	hint := "Press any key to continue"
-	cw.WriteAt(centerX-len(hint)/2, centerY+2, hint)
+	cw.WriteAt(centerX-textWidth(hint)/2, centerY+2, hint)
+}
+
+// drawNameEntryScreen draws the name prompt: a text field sized for the
+// longest name, with a blinking cursor after the typed text.
+func (c *Client) drawNameEntryScreen(centerX, centerY int) {
+	cw := c.chunkWriter
+	title := "ENTER YOUR NAME"
+	cw.WriteColoredAt(centerX-textWidth(title)/2, centerY-2, c.colors.title, title)
+
+	// "[ name_   ]", padded to a fixed width so deleted runes are cleared
+	b := append(c.hudBuf[:0], "[ "...)
+	b = append(b, c.state.NameInput...)
+	n := textWidth(c.state.NameInput)
+	if c.clock.Now().UnixMilli()/500%2 == 0 && n < config.MaxUsernameLength {
+		b = append(b, '_')
+		n++
+	}
+	for ; n < config.MaxUsernameLength; n++ {
+		b = append(b, ' ')
+	}
+	b = append(b, " ]"...)
+	c.hudBuf = b
+	field := string(b)
+	cw.WriteColoredAt(centerX-(config.MaxUsernameLength+4)/2, centerY, c.colors.hud, field)
+
+	hint := "Enter to continue, Esc to quit"
+	cw.WriteAt(centerX-textWidth(hint)/2, centerY+2, hint)
 }
 
 // drawStartScreen draws the title screen.
@@ -218,8 +498,8 @@ func (c *Client) drawStartScreen(centerX, centerY int, snapshot *server.WorldSna
 	// Find max width for centering
 	titleWidth := 0
 	for _, line := range titleArt {
-		if len(line) > titleWidth {
-			titleWidth = len(line)
+		if textWidth(line) > titleWidth {
+			titleWidth = textWidth(line)
 		}
 	}
 
@@ -227,46 +507,120 @@ func (c *Client) drawStartScreen(centerX, centerY int, snapshot *server.WorldSna
 	cw := c.chunkWriter
 	titleStartY := centerY - 7
 	for i, line := range titleArt {
-		cw.WriteAt(centerX-titleWidth/2, titleStartY+i, line)
+		cw.WriteColoredAt(centerX-titleWidth/2, titleStartY+i, c.colors.title, line)
 	}
 
 	// Subtitle
 	subtitle := "~ Multiplayer Asteroids over SSH ~"
-	cw.WriteAt(centerX-len(subtitle)/2, titleStartY+len(titleArt)+1, subtitle)
+	cw.WriteColoredAt(centerX-textWidth(subtitle)/2, titleStartY+len(titleArt)+1, c.colors.hud, subtitle)
 
 	// Controls section
 	controlsY := titleStartY + len(titleArt) + 3
-	controlHeader := "Controls"
-	cw.WriteAt(centerX-len(controlHeader)/2, controlsY, controlHeader)
-
-	controlLines := []string{
-		"W / Up  . . . . Thrust",
-		"A D / < >  . .  Rotate",
-		"SPACE  . . . . . Shoot",
-		"C  . . . . . . . Chat",
-		"Q  . . . . . . .  Quit",
-	}
-	for i, line := range controlLines {
-		cw.WriteAt(centerX-len(line)/2, controlsY+1+i, line)
-	}
+	c.drawControls(centerX, controlsY+(len(controlLines)+1)/2)
 
 	// Blinking start prompt
-	if time.Now().UnixMilli()/600%2 == 0 {
+	if c.clock.Now().UnixMilli()/600%2 == 0 {
 		prompt := ">>  Press SPACE to Start  <<"
-		cw.WriteAt(centerX-len(prompt)/2, controlsY+len(controlLines)+2, prompt)
+		cw.WriteColoredAt(centerX-textWidth(prompt)/2, controlsY+len(controlLines)+2, c.colors.warning, prompt)
 	}
 
+	// Ship selector (fixed width so shorter names don't leave residue)
+	shipName := c.state.Ship.String()
+	b := append(c.hudBuf[:0], "Ship  < "...)
+	b = append(b, shipName...)
+	for i := len(shipName); i < 7; i++ {
+		b = append(b, ' ')
+	}
+	b = append(b, " >  A/D to change"...)
+	c.hudBuf = b
+	shipLine := string(b)
+	cw.WriteColoredAt(centerX-textWidth(shipLine)/2, controlsY+len(controlLines)+3, c.colors.hud, shipLine)
+
 	// Top scores (right of controls)
 	c.drawTopScores(cw, centerX+22, controlsY, snapshot.TopScores)
+	allTimeY := controlsY
+	if len(snapshot.TopScores) > 0 {
+		allTimeY += len(snapshot.TopScores) + 2
+	}
+	c.drawAllTimeScores(cw, centerX+22, allTimeY, c.server.AllTimeScores())
 
 	// GitHub link (OSC 8 clickable hyperlink)
 	ghURL := "https://github.com/tomz197/asshteroids"
 	ghLabel := "Click to view on github"
 	ghLine := "\033]8;;" + ghURL + "\033\\" + ghLabel + "\033]8;;\033\\"
-	cw.WriteAt(centerX-len(ghLabel)/2, controlsY+len(controlLines)+4, ghLine)
+	cw.WriteAt(centerX-textWidth(ghLabel)/2, controlsY+len(controlLines)+4, ghLine)
 	ghLabel2 := "github.com/tomz197/asshteroids"
 	ghLine2 := "\033]8;;" + ghURL + "\033\\" + ghLabel2 + "\033]8;;\033\\"
-	cw.WriteAt(centerX-len(ghLabel2)/2, controlsY+len(controlLines)+5, ghLine2)
+	cw.WriteAt(centerX-textWidth(ghLabel2)/2, controlsY+len(controlLines)+5, ghLine2)
+
+	// Build version, for bug reports
+	if c.version != "" {
+		versionLine := "version " + c.version
+		cw.WriteColoredAt(centerX-textWidth(versionLine)/2, controlsY+len(controlLines)+7, c.colors.hud, versionLine)
+	}
+}
+
+// controlLines is the key reference shown on the title screen and in the
+// in-game help overlay.
+var controlLines = []string{
+	"W / Up  . . . . Thrust",
+	"A D / < >  . .  Rotate",
+	"SPACE  . . . . . Shoot",
+	"C / T  . . . . .  Chat",
+	"M  . . . . .  Settings",
+	"H  . . . .  Hyperspace",
+	"TAB  . . . . .  Scores",
+	"?  . . . . . . .  Help",
+	"Esc  . . . . . .  Back",
+	"Q  . . . . . . .  Quit",
+}
+
+// controlsWidth is the width every controls line is padded to, so the block
+// fully covers the game when drawn as an overlay.
+const controlsWidth = 26
+
+// drawControls draws the "Controls" header and key list centered on
+// (centerX, centerY).
+func (c *Client) drawControls(centerX, centerY int) {
+	cw := c.chunkWriter
+	col := centerX - controlsWidth/2
+	row := centerY - (len(controlLines)+1)/2
+	cw.WriteColoredAt(col, row, c.colors.title, c.padCentered("Controls", controlsWidth))
+	for i, line := range controlLines {
+		cw.WriteColoredAt(col, row+1+i, c.colors.hud, c.padCentered(line, controlsWidth))
+	}
+}
+
+// drawHelp draws the controls as an overlay during play, with a blank
+// margin so the game (dimmed behind it) doesn't run into the text.
+func (c *Client) drawHelp(centerX, centerY int) {
+	cw := c.chunkWriter
+	col := centerX - controlsWidth/2
+	top := centerY - (len(controlLines)+1)/2 - 1
+	bottom := top + len(controlLines) + 2
+	cw.WriteColoredAt(col, top, c.colors.hud, c.padCentered("", controlsWidth))
+	c.drawControls(centerX, centerY)
+	cw.WriteColoredAt(col, bottom, c.colors.hud, c.padCentered("", controlsWidth))
+	footer := "Any key to close"
+	if c.state.Paused {
+		footer = "Paused - any key resumes"
+	}
+	cw.WriteColoredAt(col, bottom+1, c.colors.warning, c.padCentered(footer, controlsWidth))
+}
+
+// padCentered returns text centered in a field of width columns.
+func (c *Client) padCentered(text string, width int) string {
+	pad := width - textWidth(text)
+	b := c.hudBuf[:0]
+	for i := 0; i < pad/2; i++ {
+		b = append(b, ' ')
+	}
+	b = append(b, text...)
+	for i := 0; i < pad-pad/2; i++ {
+		b = append(b, ' ')
+	}
+	c.hudBuf = b
+	return string(b)
 }
 
 // drawTopScores draws the top scores leaderboard at the given position.
@@ -275,30 +629,56 @@ func (c *Client) drawTopScores(cw *draw.ChunkWriter, col, row int, topScores []s
 		return
 	}
 	header := "Top Scores"
-	cw.WriteAt(col, row, header)
+	cw.WriteColoredAt(col, row, c.colors.title, header)
 	for i, e := range topScores {
-		// "#%-2d %-12s %6d" without fmt.Sprintf
-		b := c.hudBuf[:0]
-		b = append(b, '#')
-		b = strconv.AppendInt(b, int64(i+1), 10)
-		for len(b) < 3 {
-			b = append(b, ' ')
-		}
+		b := appendScoreRow(c.hudBuf[:0], i+1, e.Username, e.Score)
+		cw.WriteColoredAt(col, row+1+i, c.colors.hud, string(b))
+	}
+}
+
+// drawAllTimeScores draws the persisted all-time high scores at the given
+// position.
+func (c *Client) drawAllTimeScores(cw *draw.ChunkWriter, col, row int, records []server.ScoreRecord) {
+	if len(records) == 0 {
+		return
+	}
+	header := "All-Time Best"
+	cw.WriteColoredAt(col, row, c.colors.title, header)
+	for i, r := range records {
+		b := appendScoreRow(c.hudBuf[:0], i+1, r.Username, r.Score)
+		cw.WriteColoredAt(col, row+1+i, c.colors.hud, string(b))
+	}
+}
+
+// appendScoreRow appends a leaderboard row, "#%-2d %-12s %6d", to b
+// without fmt.Sprintf.
+func appendScoreRow(b []byte, rank int, username string, score int) []byte {
+	start := len(b)
+	b = append(b, '#')
+	b = strconv.AppendInt(b, int64(rank), 10)
+	for len(b)-start < 3 {
+		b = append(b, ' ')
+	}
+	b = append(b, ' ')
+	name := truncate(username, 12)
+	b = append(b, name...)
+	for n := textWidth(name); n < 12; n++ {
+		b = append(b, ' ')
+	}
+	// Right-align score in 6 chars: measure digit count, then pad
+	var numBuf [20]byte
+	digits := strconv.AppendInt(numBuf[:0], int64(score), 10)
+	for j := len(digits); j < 6; j++ {
 		b = append(b, ' ')
-		name := truncate(e.Username, 12)
-		b = append(b, name...)
-		for len(b) < 4+12 {
-			b = append(b, ' ')
-		}
-		// Right-align score in 6 chars: measure digit count, then pad
-		var numBuf [20]byte
-		digits := strconv.AppendInt(numBuf[:0], int64(e.Score), 10)
-		for j := len(digits); j < 6; j++ {
-			b = append(b, ' ')
-		}
-		b = append(b, digits...)
-		cw.WriteAt(col, row+1+i, string(b))
 	}
+	return append(b, digits...)
+}
+
+// textWidth returns the number of terminal columns s occupies, assuming
+// one column per rune. Use it instead of len for centering and padding so
+// non-ASCII text (usernames, glyphs) lines up the same as ASCII.
+func textWidth(s string) int {
+	return utf8.RuneCountInString(s)
 }
 
 // wrapText splits s into lines of at most maxWidth runes.
@@ -345,42 +725,90 @@ func truncate(s string, maxLen int) string {
 // drawPlayingHUD draws the in-game HUD.
 // Text fields use fixed-width formatting so shrinking values don't leave
 // residual characters on screen (since we no longer clear every frame).
+// Small terminals get the compact layout (see hudCompactWidth).
 func (c *Client) drawPlayingHUD(termWidth, termHeight int, snapshot *server.WorldSnapshot) {
 	cw := c.chunkWriter
+	compact := termWidth < hudCompactWidth || termHeight < hudCompactHeight
+	scoreLabel, livesLabel, playersLabel := "Score: ", "Lives: ", "Players: "
+	if compact {
+		scoreLabel, livesLabel, playersLabel = "S:", "L:", "P:"
+	}
 
 	// Score display (top left) — left-aligned, padded to fixed width
-	c.hudBuf = append(c.hudBuf[:0], "Score: "...)
+	c.hudBuf = append(c.hudBuf[:0], scoreLabel...)
 	c.hudBuf = strconv.AppendInt(c.hudBuf, int64(c.state.Score), 10)
-	for len(c.hudBuf) < len("Score: ")+8 {
+	for len(c.hudBuf) < len(scoreLabel)+8 {
+		c.hudBuf = append(c.hudBuf, ' ')
+	}
+	c.writeHUDText(2, 1, termWidth, termHeight, string(c.hudBuf))
+	waveCol := 2 + len(c.hudBuf) + 1
+	c.drawWaveHUD(waveCol, compact, snapshot)
+	c.drawPowerUpHUD(2, 2, compact)
+
+	// Lives display (top right); the last life is flagged (see lastLifeStyle)
+	lastLife, lifeColor, lifeVisible := c.lastLifeStyle()
+	c.hudBuf = append(c.hudBuf[:0], livesLabel...)
+	if lastLife && lifeColor == "" {
+		c.hudBuf = append(c.hudBuf, '*')
+	}
+	c.hudBuf = strconv.AppendInt(c.hudBuf, int64(c.state.Lives), 10)
+	if lastLife && lifeColor == "" {
+		c.hudBuf = append(c.hudBuf, '*')
+	}
+	for len(c.hudBuf) < len(livesLabel)+3 {
 		c.hudBuf = append(c.hudBuf, ' ')
 	}
-	cw.WriteAt(2, 1, string(c.hudBuf))
+	if !lifeVisible {
+		for i := range c.hudBuf {
+			c.hudBuf[i] = ' '
+		}
+	}
+	livesText := string(c.hudBuf)
+	if lifeColor == "" {
+		lifeColor = c.colors.hud
+	}
+	c.writeHUDTextColored(termWidth-len(livesText)-1, 1, termWidth, termHeight, lifeColor, livesText)
+
+	// Live players (bottom right)
+	c.hudBuf = append(c.hudBuf[:0], playersLabel...)
+	c.hudBuf = strconv.AppendInt(c.hudBuf, int64(snapshot.Players), 10)
+	for len(c.hudBuf) < len(playersLabel)+4 {
+		c.hudBuf = append(c.hudBuf, ' ')
+	}
+	livePlayersText := string(c.hudBuf)
+	playersCol := termWidth - len(livePlayersText) - 1
+	c.writeHUDText(playersCol, termHeight, termWidth, termHeight, livePlayersText)
+	c.drawPing(playersCol, compact)
 
-	// Top scores (left, below score)
+	if compact {
+		return
+	}
+
+	// Leaderboard (left, below score; under the minimap when it's top-left)
 	top5 := snapshot.TopScores
 	if len(top5) > 5 {
 		top5 = top5[:5]
 	}
-	c.drawTopScores(cw, 2, 3, top5)
-
-	// Lives display (top right)
-	c.hudBuf = append(c.hudBuf[:0], "Lives: "...)
-	c.hudBuf = strconv.AppendInt(c.hudBuf, int64(c.state.Lives), 10)
-	for len(c.hudBuf) < len("Lives: ")+3 {
-		c.hudBuf = append(c.hudBuf, ' ')
+	m := c.minimap
+	scoresRow := 3
+	if m.corner == MinimapTopLeft {
+		scoresRow = m.extrasRow(3) + 3
+	}
+	if !c.state.HideLeaderboard {
+		c.drawLeaderboard(2, scoresRow, termWidth, termHeight, top5)
 	}
-	livesText := string(c.hudBuf)
-	cw.WriteAt(termWidth-len(livesText)-1, 1, livesText)
 
-	// Minimap (top right, below lives)
-	minimapStartCol := termWidth - minimapWidth - 3
-	minimapStartRow := 3
-	if c.state.Player != nil {
-		c.drawMinimap(termWidth, termHeight, snapshot)
+	// Minimap (in its corner, see MinimapOptions)
+	minimapCol, minimapRow, fits := m.origin(termWidth, termHeight)
+	if c.state.Player != nil && fits {
+		c.drawMinimap(minimapCol, minimapRow, snapshot)
 	}
 
-	// Coordinates display (under minimap)
-	if c.state.Player != nil && minimapStartCol >= 1 && minimapStartRow+minimapHeight+2 <= termHeight {
+	// Coordinates and heading: under the minimap in top corners, above it in
+	// bottom ones
+	extrasCol := m.extrasCol(minimapCol, termWidth)
+	coordsRow := m.extrasRow(minimapRow)
+	if c.state.Player != nil && fits && coordsRow >= 1 && coordsRow <= termHeight {
 		px, py := c.state.Player.GetPosition()
 		c.hudBuf = append(c.hudBuf[:0], "X:"...)
 		c.hudBuf = strconv.AppendFloat(c.hudBuf, px, 'f', 0, 64)
@@ -392,31 +820,345 @@ func (c *Client) drawPlayingHUD(termWidth, termHeight int, snapshot *server.Worl
 		for len(c.hudBuf) < len("X:")+5+len(" Y:")+5 {
 			c.hudBuf = append(c.hudBuf, ' ')
 		}
-		cw.WriteAt(minimapStartCol, minimapStartRow+minimapHeight+2, string(c.hudBuf))
+		cw.WriteColoredAt(extrasCol, coordsRow, c.colors.hud, string(c.hudBuf))
+		c.canvas.MarkTextDirty(extrasCol, coordsRow, len(c.hudBuf))
 	}
 
-	// Live players (bottom right)
-	c.hudBuf = append(c.hudBuf[:0], "Players: "...)
-	c.hudBuf = strconv.AppendInt(c.hudBuf, int64(snapshot.Players), 10)
-	for len(c.hudBuf) < len("Players: ")+4 {
+	// Heading indicator (after coordinates)
+	headingRow := coordsRow + 1
+	if c.state.Player != nil && fits && headingRow >= 1 && headingRow <= termHeight {
+		c.drawHeading(extrasCol, headingRow, snapshot)
+	}
+}
+
+// leaderboardWidth is the inner width of the leaderboard panel: rank,
+// username and a 7-digit score.
+const leaderboardWidth = 3 + 1 + config.MaxUsernameLength + 1 + 7
+
+// drawLeaderboard draws the top scores as a boxed panel at the given 1-based
+// position, with this client's row in bright yellow. Its cells are marked
+// dirty like the minimap's, so rows that change or go away (and the whole
+// panel, once hidden) are cleaned up. Skipped when it doesn't fit.
+func (c *Client) drawLeaderboard(startCol, startRow, termWidth, termHeight int, entries []server.TopScoreEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	if startCol < 1 || startCol+leaderboardWidth+1 > termWidth || startRow+len(entries)+1 > termHeight {
+		return
+	}
+	cw := c.chunkWriter
+	box := c.canvas.Box()
+
+	// Top border with the title set into it: ┌ Top Scores ───┐
+	title := " Top Scores "
+	b := append(c.hudBuf[:0], box.TopLeft...)
+	b = append(b, box.Horizontal...)
+	b = append(b, title...)
+	for n := 1 + len(title); n < leaderboardWidth; n++ {
+		b = append(b, box.Horizontal...)
+	}
+	b = append(b, box.TopRight...)
+	cw.WriteColoredAt(startCol, startRow, c.colors.title, string(b))
+	c.canvas.MarkTextDirty(startCol, startRow, leaderboardWidth+2)
+
+	for i, e := range entries {
+		row := startRow + 1 + i
+		own := e.ClientID == c.handle.ID
+		color := c.colors.hud
+		rank := byte('#')
+		if own {
+			if c.colorLevel != draw.ColorLevelNone {
+				color = draw.ColorBrightYellow
+			} else {
+				rank = '>' // No color to pick out this client's row
+			}
+		}
+
+		// "#%-2d %-16s %7d" without fmt.Sprintf
+		b = append(c.hudBuf[:0], rank)
+		b = strconv.AppendInt(b, int64(i+1), 10)
+		for len(b) < 3 {
+			b = append(b, ' ')
+		}
+		b = append(b, ' ')
+		name := truncate(e.Username, config.MaxUsernameLength)
+		b = append(b, name...)
+		for n := textWidth(name); n < config.MaxUsernameLength; n++ {
+			b = append(b, ' ')
+		}
+		var numBuf [20]byte
+		digits := strconv.AppendInt(numBuf[:0], int64(e.Score), 10)
+		for j := len(digits); j < 8; j++ {
+			b = append(b, ' ')
+		}
+		b = append(b, digits...)
+		c.hudBuf = b
+
+		cw.WriteAt(startCol, row, box.Vertical)
+		cw.WriteColoredAt(startCol+1, row, color, string(b))
+		cw.WriteAt(startCol+1+leaderboardWidth, row, box.Vertical)
+		c.canvas.MarkTextDirty(startCol, row, leaderboardWidth+2)
+	}
+
+	b = append(c.hudBuf[:0], box.BottomLeft...)
+	for n := 0; n < leaderboardWidth; n++ {
+		b = append(b, box.Horizontal...)
+	}
+	b = append(b, box.BottomRight...)
+	c.hudBuf = b
+	bottom := startRow + 1 + len(entries)
+	cw.WriteColoredAt(startCol, bottom, c.colors.title, string(b))
+	c.canvas.MarkTextDirty(startCol, bottom, leaderboardWidth+2)
+}
+
+// writeHUDText writes a HUD field at the 1-based terminal position, clipped
+// to the terminal so tiny windows don't wrap or scroll. Fields that start
+// off-screen are dropped.
+func (c *Client) writeHUDText(col, row, termWidth, termHeight int, text string) {
+	c.writeHUDTextColored(col, row, termWidth, termHeight, c.colors.hud, text)
+}
+
+// writeHUDTextColored is writeHUDText in the given color.
+func (c *Client) writeHUDTextColored(col, row, termWidth, termHeight int, color, text string) {
+	if row < 1 || row > termHeight {
+		return
+	}
+	if col < 1 {
+		col = 1
+	}
+	room := termWidth - col + 1
+	if room <= 0 {
+		return
+	}
+	c.chunkWriter.WriteColoredAt(col, row, color, truncate(text, room))
+}
+
+// drawPowerUpHUD lists the ship's active weapon power-ups with their
+// seconds left, e.g. "Rapid 7s  Triple 3s" ("R7 T3" when compact).
+func (c *Client) drawPowerUpHUD(col, row int, compact bool) {
+	ship := c.state.Player
+	if ship == nil {
+		return
+	}
+	c.hudBuf = c.hudBuf[:0]
+	add := func(name, short string, seconds float64) {
+		if seconds <= 0 {
+			return
+		}
+		switch {
+		case compact && len(c.hudBuf) > 0:
+			c.hudBuf = append(c.hudBuf, ' ')
+		case len(c.hudBuf) > 0:
+			c.hudBuf = append(c.hudBuf, "  "...)
+		}
+		if compact {
+			c.hudBuf = append(c.hudBuf, short...)
+		} else {
+			c.hudBuf = append(c.hudBuf, name...)
+			c.hudBuf = append(c.hudBuf, ' ')
+		}
+		c.hudBuf = strconv.AppendInt(c.hudBuf, int64(math.Ceil(seconds)), 10)
+		if !compact {
+			c.hudBuf = append(c.hudBuf, 's')
+		}
+	}
+	add("Rapid", "R", ship.RapidFireTime)
+	add("Triple", "T", ship.TripleShotTime)
+	add("Pierce", "P", ship.PierceTime)
+	if len(c.hudBuf) == 0 {
+		return
+	}
+	termWidth, termHeight := c.canvas.TerminalWidth(), c.canvas.TerminalHeight()
+	c.writeHUDTextColored(col, row, termWidth, termHeight, c.colors.warning, string(c.hudBuf))
+	c.canvas.MarkTextDirty(col, row, len(c.hudBuf))
+}
+
+// drawWaveHUD shows the current wave next to the score, or without waves the
+// asteroid density while the world is still ramping toward its target, so
+// players can tell why the field is filling up. Shown only when it says
+// something; its cells are marked dirty so it's cleaned up once hidden.
+func (c *Client) drawWaveHUD(col int, compact bool, snapshot *server.WorldSnapshot) {
+	waveLabel, densityLabel := "Wave ", "Density "
+	if compact {
+		waveLabel, densityLabel = "W:", "D:"
+	}
+	c.hudBuf = c.hudBuf[:0]
+	switch density := int(math.Round(snapshot.Difficulty * 100)); {
+	case snapshot.Wave > 0:
+		c.hudBuf = append(c.hudBuf, waveLabel...)
+		c.hudBuf = strconv.AppendInt(c.hudBuf, int64(snapshot.Wave), 10)
+	case density != 100:
+		c.hudBuf = append(c.hudBuf, densityLabel...)
+		c.hudBuf = strconv.AppendInt(c.hudBuf, int64(density), 10)
+		c.hudBuf = append(c.hudBuf, '%')
+	default:
+		return
+	}
+	for len(c.hudBuf) < len(densityLabel)+5 {
 		c.hudBuf = append(c.hudBuf, ' ')
 	}
-	livePlayersText := string(c.hudBuf)
-	cw.WriteAt(termWidth-len(livePlayersText)-1, termHeight, livePlayersText)
+	termWidth, termHeight := c.canvas.TerminalWidth(), c.canvas.TerminalHeight()
+	c.writeHUDText(col, 1, termWidth, termHeight, string(c.hudBuf))
+	c.canvas.MarkTextDirty(col, 1, len(c.hudBuf))
 }
 
-// drawMinimap draws a small overview of the world showing the local player and others.
-// Uses half-block characters (▀▄█) for 2x vertical resolution. Self is bright cyan, others dim.
-func (c *Client) drawMinimap(termWidth, termHeight int, snapshot *server.WorldSnapshot) {
+// drawPing shows the round-trip time to the player's terminal on the bottom
+// row, ending just left of endCol, in the warning color once it reaches
+// config.PingWarnThreshold. Hidden until a measurement arrives.
+func (c *Client) drawPing(endCol int, compact bool) {
+	if c.pingFunc == nil {
+		return
+	}
+	rtt := c.pingFunc()
+	if rtt <= 0 {
+		return
+	}
+	label := "Ping: "
+	if compact {
+		label = ""
+	}
+	c.hudBuf = append(c.hudBuf[:0], label...)
+	c.hudBuf = strconv.AppendInt(c.hudBuf, min(rtt.Milliseconds(), 9999), 10)
+	c.hudBuf = append(c.hudBuf, "ms"...)
+	for len(c.hudBuf) < len(label)+len("9999ms ") {
+		c.hudBuf = append(c.hudBuf, ' ')
+	}
+	color := c.colors.hud
+	if rtt >= config.PingWarnThreshold {
+		color = c.colors.warning
+	}
+	termWidth, termHeight := c.canvas.TerminalWidth(), c.canvas.TerminalHeight()
+	col := endCol - len(c.hudBuf) - 1
+	c.writeHUDTextColored(col, termHeight, termWidth, termHeight, color, string(c.hudBuf))
+	c.canvas.MarkTextDirty(col, termHeight, len(c.hudBuf))
+}
+
+// lastLifeBlinkMillis is the on/off period of the last-life lives counter.
+const lastLifeBlinkMillis = 400
+
+// lastLifeStyle reports whether the player is on their last life and how to
+// show the lives counter: in red when the terminal has color (color is ""
+// otherwise, and the count is wrapped in asterisks instead), blinking unless
+// the warning blink is turned off in settings.
+func (c *Client) lastLifeStyle() (lastLife bool, color string, visible bool) {
+	if c.state.Lives != 1 {
+		return false, "", true
+	}
+	if c.colorLevel != draw.ColorLevelNone {
+		color = draw.ColorBrightRed
+	}
+	visible = c.state.NoWarningBlink || c.clock.Now().UnixMilli()/lastLifeBlinkMillis%2 == 0
+	return true, color, visible
+}
+
+// compassArrows maps 45° heading sectors (clockwise from up) to arrow glyphs.
+var compassArrows = [8]string{"↑", "↗", "→", "↘", "↓", "↙", "←", "↖"}
+
+// asciiCompassArrows are compassArrows for ASCII mode.
+var asciiCompassArrows = [8]string{"^", "/", ">", "\\", "v", "/", "<", "\\"}
+
+// headingDegrees converts an angle in radians (0 = right, y grows downward)
+// to a compass heading in whole degrees clockwise from up, in [0, 360).
+func headingDegrees(angle float64) int {
+	deg := (int(math.Round(angle*180/math.Pi)) + 90) % 360
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// compassArrow returns the arrow glyph closest to the given angle, in ASCII
+// when ascii is set.
+func compassArrow(angle float64, ascii bool) string {
+	if ascii {
+		return asciiCompassArrows[(headingDegrees(angle)+22)/45%8]
+	}
+	return compassArrows[(headingDegrees(angle)+22)/45%8]
+}
+
+// drawHeading draws the ship's compass heading and an arrow pointing toward
+// the world center (shortest path across the wrapping world edges).
+// Fixed-width so changing values don't leave residual characters.
+func (c *Client) drawHeading(col, row int, snapshot *server.WorldSnapshot) {
+	player := c.state.Player
+	ascii := c.canvas.ASCII()
+	b := append(c.hudBuf[:0], "HDG "...)
+	deg := headingDegrees(player.Angle)
+	if deg < 100 {
+		b = append(b, '0')
+	}
+	if deg < 10 {
+		b = append(b, '0')
+	}
+	b = strconv.AppendInt(b, int64(deg), 10)
+	if ascii {
+		b = append(b, ' ')
+	} else {
+		b = append(b, "°"...)
+	}
+	b = append(b, ' ')
+	b = append(b, compassArrow(player.Angle, ascii)...)
+
+	// Direction to world center, wrap-aware
+	px, py := player.GetPosition()
+	dx, dy := worldDelta(snapshot, float64(snapshot.World.CenterX)-px, float64(snapshot.World.CenterY)-py)
+	b = append(b, "  CTR "...)
+	if dx*dx+dy*dy < 1 {
+		if ascii {
+			b = append(b, '.')
+		} else {
+			b = append(b, "·"...)
+		}
+	} else {
+		b = append(b, compassArrow(math.Atan2(dy, dx), ascii)...)
+	}
+	c.hudBuf = b
+
+	text := string(b)
+	c.chunkWriter.WriteColoredAt(col, row, c.colors.hud, text)
+	c.canvas.MarkTextDirty(col, row, utf8.RuneCountInString(text))
+}
+
+// worldDelta returns the offset (dx, dy) between two points of the
+// snapshot's world the short way: across a wrapping edge if that is nearer,
+// or as is in a bounded world, where edges don't wrap.
+func worldDelta(snapshot *server.WorldSnapshot, dx, dy float64) (float64, float64) {
+	if snapshot.Bounded {
+		return dx, dy
+	}
+	return wrapDelta(dx, float64(snapshot.World.Width)), wrapDelta(dy, float64(snapshot.World.Height))
+}
+
+// wrapDelta shortens a coordinate delta to the nearest equivalent in a
+// wrapping dimension of the given size.
+func wrapDelta(d, size float64) float64 {
+	if size <= 0 {
+		return d
+	}
+	if d > size/2 {
+		d -= size
+	} else if d < -size/2 {
+		d += size
+	}
+	return d
+}
+
+// drawMinimap draws a small overview of the world showing the local player
+// and others, with its top-left border corner at startCol, startRow.
+// Uses half-block characters (▀▄█) for 2x vertical resolution. Self and others use
+// the theme's self and enemy colors.
+func (c *Client) drawMinimap(startCol, startRow int, snapshot *server.WorldSnapshot) {
 	worldW := float64(snapshot.World.Width)
 	worldH := float64(snapshot.World.Height)
 	if worldW <= 0 || worldH <= 0 {
 		return
 	}
+	m := c.minimap
+	minimapWidth, minimapHeight, minimapSubRows := m.width, m.height, m.subRows()
 
 	// Build minimap grid: 0=empty, 1=other, 2=self (self overwrites)
-	grid := &c.state.minimapGrid
-	*grid = [minimapSubRows][minimapWidth]byte{} // Clear
+	grid := m.grid
+	clear(grid)
 
 	// Map all players to grid cells (2x vertical resolution)
 	for _, user := range snapshot.UserObjects {
@@ -435,50 +1177,63 @@ func (c *Client) drawMinimap(termWidth, termHeight int, snapshot *server.WorldSn
 		if subRow >= minimapSubRows {
 			subRow = minimapSubRows - 1
 		}
-		if user == c.state.Player {
-			grid[subRow][col] = 2 // Self
-		} else if grid[subRow][col] == 0 {
-			grid[subRow][col] = 1 // Other (don't overwrite self)
+		cell := subRow*minimapWidth + col
+		if c.isOwnShip(user) {
+			grid[cell] = 2 // Self
+		} else if grid[cell] == 0 {
+			grid[cell] = 1 // Other (don't overwrite self)
 		}
 	}
 
-	// Position: top-right, below lives
-	startCol := termWidth - minimapWidth - 3 // border + padding
-	startRow := 3
-	if startCol < 1 || startRow+minimapHeight+1 > termHeight {
-		return // Not enough space
+	// Accumulate minimap output for chunked write
+	selfColor := c.colors.self
+	if selfColor == "" {
+		selfColor = draw.ColorReset
+	}
+	otherColor := c.colors.enemy
+	if otherColor == "" {
+		otherColor = draw.ColorReset
+	}
+
+	// ASCII mode swaps the frame and half-blocks for plain characters
+	m.setBox(c.canvas.Box())
+	full, upper, lower := draw.BlockFull, draw.BlockUpperHalf, draw.BlockLowerHalf
+	if c.canvas.ASCII() {
+		full, upper, lower = draw.ASCIIFull, draw.ASCIIUpperHalf, draw.ASCIILowerHalf
 	}
 
-	// Accumulate minimap output for chunked write
 	cw := c.chunkWriter
-	cw.WriteAt(startCol, startRow, minimapTopBorder)
+	cw.WriteAt(startCol, startRow, m.topBorder)
 	c.canvas.MarkTextDirty(startCol, startRow, minimapWidth+2)
 
 	// Each terminal row combines 2 sub-rows via half-block characters (▀▄█)
 	for termRow := 0; termRow < minimapHeight; termRow++ {
-		cw.WriteAt(startCol, startRow+1+termRow, "│")
+		cw.WriteAt(startCol, startRow+1+termRow, m.box.Vertical)
 		curColor := ""
 		for col := 0; col < minimapWidth; col++ {
-			top := grid[termRow*2][col]
-			bot := grid[termRow*2+1][col]
+			top := grid[termRow*2*minimapWidth+col]
+			bot := grid[(termRow*2+1)*minimapWidth+col]
 			topFilled := top != 0
 			botFilled := bot != 0
 			isSelf := top == 2 || bot == 2
-			wantColor := draw.ColorReset // Default color for others
+			wantColor := otherColor
 			if isSelf {
-				wantColor = draw.ColorBrightCyan // Bright cyan for current player
+				wantColor = selfColor
 			}
 			var r rune
 			switch {
 			case topFilled && botFilled:
-				r = draw.BlockFull
+				r = full
 			case topFilled && !botFilled:
-				r = draw.BlockUpperHalf
+				r = upper
 			case !topFilled && botFilled:
-				r = draw.BlockLowerHalf
+				r = lower
 			default:
 				r = ' '
 			}
+			if isSelf && c.colors.selfMarker != 0 {
+				r = c.colors.selfMarker
+			}
 			if r != ' ' {
 				if curColor != wantColor {
 					cw.WriteString(wantColor)
@@ -493,15 +1248,91 @@ func (c *Client) drawMinimap(termWidth, termHeight int, snapshot *server.WorldSn
 		if curColor != "" {
 			cw.WriteString(draw.ColorReset)
 		}
-		cw.WriteString("│")
+		cw.WriteString(m.box.Vertical)
 		c.canvas.MarkTextDirty(startCol, startRow+1+termRow, minimapWidth+2)
 	}
 
-	cw.WriteAt(startCol, startRow+1+minimapHeight, minimapBottomBorder)
+	cw.WriteAt(startCol, startRow+1+minimapHeight, m.bottomBorder)
 	c.canvas.MarkTextDirty(startCol, startRow+1+minimapHeight, minimapWidth+2)
 
 }
 
+// depthDimFrom is how far from the view center, as a fraction of the
+// distance to the view edge, other ships start being drawn dimmed.
+const depthDimFrom = 0.6
+
+// distantShipColor returns the dim color for another player's ship that is
+// far from the camera when depth dimming is on, or "" to draw it normally.
+// Terminals without color draw every ship normally.
+func (c *Client) distantShipColor(u *object.User, snapshot *server.WorldSnapshot) string {
+	if !c.state.DepthDim || c.colorLevel == draw.ColorLevelNone {
+		return ""
+	}
+	dx, dy := worldDelta(snapshot, u.X-c.state.Camera.X, u.Y-c.state.Camera.Y)
+	reach := max(math.Abs(dx)/(float64(c.state.View.Width)/2), math.Abs(dy)/(float64(c.state.View.Height)/2))
+	if reach < depthDimFrom {
+		return ""
+	}
+	if c.colorLevel >= draw.ColorLevel256 {
+		return distantColor256
+	}
+	return draw.ColorBrightBlack
+}
+
+// shipColor returns the color of another player's ship, for their name:
+// their slot in the ship palette, or the theme's enemy color without one.
+func (c *Client) shipColor(u *object.User) string {
+	if n := len(c.colors.ships); n > 0 {
+		return c.colors.ships[int(u.Color)%n]
+	}
+	return c.colors.enemy
+}
+
+// distantColor256 is the dim gray for distant ships on 256-color terminals.
+var distantColor256 = draw.Color256(243)
+
+// expiringColor returns the dim color projectiles fade to near the end of
+// their range, or "" without color support (they blink instead).
+func (c *Client) expiringColor() string {
+	switch {
+	case c.colorLevel == draw.ColorLevelNone:
+		return ""
+	case c.colorLevel >= draw.ColorLevel256:
+		return expiringColor256
+	default:
+		return draw.ColorBrightBlack
+	}
+}
+
+// expiringColor256 is the dim gray of expiring projectiles on 256-color terminals.
+var expiringColor256 = draw.Color256(240)
+
+// fadeColors steps from bright white down to a light gray close to the usual
+// default foreground, on the xterm 256-color palette.
+var fadeColors = func() []string {
+	grays := []uint8{231, 255, 254, 253, 252, 251, 250}
+	colors := make([]string, len(grays))
+	for i, g := range grays {
+		colors[i] = draw.Color256(g)
+	}
+	return colors
+}()
+
+// invincibilityFade returns the color for the local ship during the last
+// config.InvincibilityFade of invincibility, so players see when they become
+// vulnerable. Returns "" outside that window or when the terminal has fewer
+// than 256 colors, in which case the ship keeps blinking instead.
+func (c *Client) invincibilityFade() string {
+	fade := config.InvincibilityFade.Seconds()
+	t := c.state.InvincibleTime
+	if c.colorLevel < draw.ColorLevel256 || fade <= 0 || t <= 0 || t > fade {
+		return ""
+	}
+	// t/fade runs from 1 (bright) to 0 (normal)
+	step := int(float64(len(fadeColors)) * (1 - t/fade))
+	return fadeColors[min(step, len(fadeColors)-1)]
+}
+
 // drawDeadScreen draws the death/game over screen.
 func (c *Client) drawDeadScreen(centerX, centerY int) {
 	var titleArt []string
@@ -526,8 +1357,8 @@ func (c *Client) drawDeadScreen(centerX, centerY int) {
 	// Find max width for centering
 	titleWidth := 0
 	for _, line := range titleArt {
-		if len(line) > titleWidth {
-			titleWidth = len(line)
+		if textWidth(line) > titleWidth {
+			titleWidth = textWidth(line)
 		}
 	}
 
@@ -535,14 +1366,14 @@ func (c *Client) drawDeadScreen(centerX, centerY int) {
 	cw := c.chunkWriter
 	titleStartY := centerY - 6
 	for i, line := range titleArt {
-		cw.WriteAt(centerX-titleWidth/2, titleStartY+i, line)
+		cw.WriteColoredAt(centerX-titleWidth/2, titleStartY+i, c.colors.warning, line)
 	}
 
 	// Killed by (when killed by another player)
 	offset := 0
 	if c.state.KilledBy != "" {
 		killedByText := "Killed by " + c.state.KilledBy
-		cw.WriteAt(centerX-len(killedByText)/2, titleStartY+len(titleArt)+offset, killedByText)
+		cw.WriteColoredAt(centerX-textWidth(killedByText)/2, titleStartY+len(titleArt)+offset, c.colors.warning, killedByText)
 		offset++
 	}
 
@@ -551,7 +1382,7 @@ func (c *Client) drawDeadScreen(centerX, centerY int) {
 	b = append(b, "Score: "...)
 	b = strconv.AppendInt(b, int64(c.state.Score), 10)
 	scoreText := string(b)
-	cw.WriteAt(centerX-len(scoreText)/2, titleStartY+len(titleArt)+offset+1, scoreText)
+	cw.WriteColoredAt(centerX-textWidth(scoreText)/2, titleStartY+len(titleArt)+offset+1, c.colors.hud, scoreText)
 
 	// Lives or game over info
 	if c.state.Lives > 0 {
@@ -559,7 +1390,30 @@ func (c *Client) drawDeadScreen(centerX, centerY int) {
 		b = append(b, "Lives remaining: "...)
 		b = strconv.AppendInt(b, int64(c.state.Lives), 10)
 		livesText := string(b)
-		cw.WriteAt(centerX-len(livesText)/2, titleStartY+len(titleArt)+3, livesText)
+		cw.WriteColoredAt(centerX-textWidth(livesText)/2, titleStartY+len(titleArt)+3, c.colors.hud, livesText)
+	} else if c.state.NewPersonalBest {
+		bestText := "*** NEW PERSONAL BEST! ***"
+		cw.WriteColoredAt(centerX-textWidth(bestText)/2, titleStartY+len(titleArt)+3, c.colors.title, bestText)
+	} else if c.state.PersonalBest > 0 {
+		b = b[:0]
+		b = append(b, "Personal Best: "...)
+		b = strconv.AppendInt(b, int64(c.state.PersonalBest), 10)
+		bestText := string(b)
+		cw.WriteColoredAt(centerX-textWidth(bestText)/2, titleStartY+len(titleArt)+3, c.colors.hud, bestText)
+	}
+
+	// Shot accuracy for the session
+	if c.state.Stats.ShotsFired > 0 {
+		b = b[:0]
+		b = append(b, "Accuracy: "...)
+		b = strconv.AppendFloat(b, c.state.Stats.Accuracy(), 'f', 0, 64)
+		b = append(b, "% ("...)
+		b = strconv.AppendInt(b, int64(c.state.Stats.ShotsHit), 10)
+		b = append(b, '/')
+		b = strconv.AppendInt(b, int64(c.state.Stats.ShotsFired), 10)
+		b = append(b, ')')
+		accuracyText := string(b)
+		cw.WriteColoredAt(centerX-textWidth(accuracyText)/2, titleStartY+len(titleArt)+4, c.colors.hud, accuracyText)
 	}
 
 	// Respawn countdown or prompt
@@ -569,31 +1423,90 @@ func (c *Client) drawDeadScreen(centerX, centerY int) {
 		b = strconv.AppendFloat(b, c.state.RespawnTimeRemaining, 'f', 1, 64)
 		b = append(b, " seconds..."...)
 		countdown := string(b)
-		cw.WriteAt(centerX-len(countdown)/2, titleStartY+len(titleArt)+5, countdown)
-	} else if time.Now().UnixMilli()/600%2 == 0 {
+		cw.WriteColoredAt(centerX-textWidth(countdown)/2, titleStartY+len(titleArt)+5, c.colors.hud, countdown)
+	} else if c.clock.Now().UnixMilli()/600%2 == 0 {
 		var prompt string
 		if c.state.Lives > 0 {
 			prompt = ">>  Press SPACE to Continue  <<"
 		} else {
 			prompt = ">>  Press SPACE to Restart  <<"
 		}
-		cw.WriteAt(centerX-len(prompt)/2, titleStartY+len(titleArt)+5, prompt)
+		cw.WriteColoredAt(centerX-textWidth(prompt)/2, titleStartY+len(titleArt)+5, c.colors.warning, prompt)
 	}
 	escapeHint := "ESC to return to menu"
-	cw.WriteAt(centerX-len(escapeHint)/2, titleStartY+len(titleArt)+7, escapeHint)
+	cw.WriteAt(centerX-textWidth(escapeHint)/2, titleStartY+len(titleArt)+7, escapeHint)
+}
+
+// drawSummaryScreen draws the end-of-session statistics shown before quitting.
+func (c *Client) drawSummaryScreen(centerX, centerY int) {
+	cw := c.chunkWriter
+	sum := c.state.Summary
+
+	title := "SESSION SUMMARY"
+	cw.WriteColoredAt(centerX-textWidth(title)/2, centerY-6, c.colors.title, title)
+
+	// Each row is "label . . . value" padded to a fixed width so the values align
+	const rowWidth = 34
+	row := centerY - 4
+	line := func(label string, value []byte) {
+		b := append(c.hudBuf[:0], label...)
+		b = append(b, ' ')
+		for len(b)+len(value)+1 < rowWidth {
+			if len(b)%2 == 0 {
+				b = append(b, '.')
+			} else {
+				b = append(b, ' ')
+			}
+		}
+		b = append(b, ' ')
+		b = append(b, value...)
+		c.hudBuf = b
+		cw.WriteColoredAt(centerX-rowWidth/2, row, c.colors.hud, string(b))
+		row++
+	}
+
+	var v [32]byte
+	secs := int(sum.TimePlayed)
+	t := strconv.AppendInt(v[:0], int64(secs/60), 10)
+	t = append(t, ':')
+	if secs%60 < 10 {
+		t = append(t, '0')
+	}
+	t = strconv.AppendInt(t, int64(secs%60), 10)
+	line("Time played", t)
+	line("Best score", strconv.AppendInt(v[:0], int64(sum.BestScore), 10))
+
+	r := append(v[:0], '#')
+	r = strconv.AppendInt(r, int64(sum.Rank), 10)
+	r = append(r, " of "...)
+	r = strconv.AppendInt(r, int64(sum.Players), 10)
+	line("Final rank", r)
+
+	line("Asteroids destroyed", strconv.AppendInt(v[:0], int64(sum.AsteroidsDestroyed), 10))
+	line("Players destroyed", strconv.AppendInt(v[:0], int64(sum.Kills), 10))
+	line("Deaths", strconv.AppendInt(v[:0], int64(sum.Deaths), 10))
+
+	a := strconv.AppendFloat(v[:0], sum.Accuracy(), 'f', 0, 64)
+	a = append(a, '%')
+	line("Accuracy", a)
+
+	prompt := "Press Q or SPACE to quit"
+	cw.WriteColoredAt(centerX-textWidth(prompt)/2, row+2, c.colors.warning, prompt)
+	hint := "ESC to keep playing"
+	cw.WriteAt(centerX-textWidth(hint)/2, row+3, hint)
 }
 
 // drawShutdownScreen draws the server shutdown notification screen.
 func (c *Client) drawShutdownScreen(centerX, centerY int) {
 	cw := c.chunkWriter
 	title := "SERVER SHUTTING DOWN"
-	cw.WriteAt(centerX-len(title)/2, centerY-3, title)
+	cw.WriteColoredAt(centerX-textWidth(title)/2, centerY-3, c.colors.warning, title)
 
 	msg1 := "The server is restarting for maintenance."
-	cw.WriteAt(centerX-len(msg1)/2, centerY-1, msg1)
+	cw.WriteColoredAt(centerX-textWidth(msg1)/2, centerY-1, c.colors.hud, msg1)
 
 	msg2 := "Please reconnect in a moment."
-	cw.WriteAt(centerX-len(msg2)/2, centerY, msg2)
+	cw.WriteColoredAt(centerX-textWidth(msg2)/2, centerY, c.colors.hud, msg2)
 
 	remaining := int(c.state.shutdownTimer) + 1
 	b := c.hudBuf[:0]
@@ -601,26 +1514,29 @@ func (c *Client) drawShutdownScreen(centerX, centerY int) {
 	b = strconv.AppendInt(b, int64(remaining), 10)
 	b = append(b, " seconds..."...)
 	countdown := string(b)
-	cw.WriteAt(centerX-len(countdown)/2, centerY+2, countdown)
+	cw.WriteColoredAt(centerX-textWidth(countdown)/2, centerY+2, c.colors.warning, countdown)
 
 	hint := "Press Q to disconnect now"
-	cw.WriteAt(centerX-len(hint)/2, centerY+4, hint)
+	cw.WriteAt(centerX-textWidth(hint)/2, centerY+4, hint)
 }
 
 // drawPlayerNames draws usernames above other players' ships.
 // Marks the drawn cells as dirty so the canvas overwrites them next frame,
 // preventing stale name text from persisting when ships move.
-func (c *Client) drawPlayerNames(userObjects []*object.User, world object.Screen) {
+func (c *Client) drawPlayerNames(userObjects []*object.User, ctx object.DrawContext) {
+	if c.state.HideNames {
+		return // Ships stay visible; the minimap and scoreboard still identify players
+	}
 	termWidth := c.canvas.TerminalWidth()
 	termHeight := c.canvas.TerminalHeight()
 
 	for _, user := range userObjects {
-		if user == c.state.Player || user.Username == "" {
+		if c.isOwnShip(user) || user.Username == "" {
 			continue
 		}
 
 		// Get screen positions (handles world wrapping)
-		positions := object.WorldToScreen(user.X, user.Y, c.state.Camera, c.state.View, world)
+		positions := ctx.ToScreen(user.X, user.Y)
 		for i := 0; i < positions.Count; i++ {
 			pos := positions.Positions[i]
 
@@ -628,20 +1544,20 @@ func (c *Client) drawPlayerNames(userObjects []*object.User, world object.Screen
 			col, row := c.canvas.LogicalToTerminal(pos.X, pos.Y-user.Size-2)
 
 			// Center the username horizontally
-			col -= len(user.Username) / 2
+			col -= textWidth(user.Username) / 2
 
 			// Clamp to screen bounds
 			if row < 1 || row > termHeight {
 				continue
 			}
-			if col < 1 || col+len(user.Username) > termWidth {
+			if col < 1 || col+textWidth(user.Username) > termWidth {
 				continue
 			}
 
-			c.chunkWriter.WriteAt(col, row, user.Username)
+			c.chunkWriter.WriteColoredAt(col, row, c.shipColor(user), user.Username)
 
 			// Mark these cells dirty so the canvas cleans them up next frame
-			c.canvas.MarkTextDirty(col, row, len(user.Username))
+			c.canvas.MarkTextDirty(col, row, textWidth(user.Username))
 		}
 	}
 }
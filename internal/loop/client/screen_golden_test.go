@@ -0,0 +1,124 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomz197/asteroids/internal/clock"
+	"github.com/tomz197/asteroids/internal/draw"
+	"github.com/tomz197/asteroids/internal/loop/server"
+)
+
+// update rewrites the golden files instead of comparing against them. Run
+// after an intentional screen change, then review the diff:
+//
+//	go test ./internal/loop/client -run TestScreenGolden -update
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// goldenSizes are the terminal sizes each screen is rendered at: the classic
+// default and a large terminal.
+var goldenSizes = [][2]int{{80, 24}, {160, 48}}
+
+// newTestClient returns a client of an idle server on a terminal of the
+// given size, in a colored theme. Its output is collected in out rather than
+// sent. The clock is fixed on the visible phase of blinking prompts, so the
+// output is deterministic.
+func newTestClient(t *testing.T, width, height int, out *bytes.Buffer) *Client {
+	t.Helper()
+	t.Setenv("SCORES_FILE", "")
+	s, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	c := NewClient(s, bufio.NewReader(strings.NewReader("")), io.Discard, ClientOptions{
+		TermSizeFunc: func() (int, int, error) { return width, height, nil },
+		Username:     "pilot",
+		Theme:        "amber", // The default theme leaves text uncolored
+		ColorLevel:   draw.ColorLevel256,
+		Version:      "v1.2.3",
+		Clock:        clock.NewFake(time.Unix(0, 0)),
+	})
+	t.Cleanup(func() { c.writer.Close() })
+	c.chunkWriter = draw.NewChunkWriter(out, c.canvas.OffsetCol(), c.canvas.OffsetRow())
+	return c
+}
+
+// renderScreen returns the bytes drawUI writes for the client state set up by
+// setup, on a terminal of the given size (see newTestClient).
+func renderScreen(t *testing.T, width, height int, snapshot *server.WorldSnapshot, setup func(*ClientState)) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	c := newTestClient(t, width, height, &out)
+	setup(c.state)
+	c.drawUI(snapshot)
+	if err := c.chunkWriter.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	return out.Bytes()
+}
+
+func TestScreenGolden(t *testing.T) {
+	scores := &server.WorldSnapshot{TopScores: []server.TopScoreEntry{
+		{Username: "Žofie", Score: 4200, ClientID: 1},
+		{Username: "pilot", Score: 1300, ClientID: 2},
+	}}
+	screens := []struct {
+		name     string
+		snapshot *server.WorldSnapshot
+		setup    func(*ClientState)
+	}{
+		{"title", scores, func(st *ClientState) {
+			st.GameState = GameStateStart
+		}},
+		{"dead", &server.WorldSnapshot{}, func(st *ClientState) {
+			st.GameState = GameStateDead
+			st.Lives = 2
+			st.Score = 750
+			st.KilledBy = "Žofie"
+			st.RespawnTimeRemaining = 1.5
+		}},
+		{"game-over", &server.WorldSnapshot{}, func(st *ClientState) {
+			st.GameState = GameStateDead
+			st.Score = 1300
+			st.NewPersonalBest = true
+			st.Stats.ShotsFired, st.Stats.ShotsHit = 40, 10
+		}},
+		{"shutdown", &server.WorldSnapshot{}, func(st *ClientState) {
+			st.GameState = GameStateShutdown
+			st.shutdownTimer = 4.2
+		}},
+	}
+	for _, screen := range screens {
+		for _, size := range goldenSizes {
+			name := fmt.Sprintf("%s-%dx%d", screen.name, size[0], size[1])
+			t.Run(name, func(t *testing.T) {
+				got := renderScreen(t, size[0], size[1], screen.snapshot, screen.setup)
+				path := filepath.Join("testdata", name+".golden")
+				if *update {
+					if err := os.MkdirAll("testdata", 0o755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, got, 0o644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				want, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("%v (run with -update to create it)", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("output differs from %s (run with -update if the change is intended)\ngot:  %q\nwant: %q", path, got, want)
+				}
+			})
+		}
+	}
+}
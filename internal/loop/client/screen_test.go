@@ -0,0 +1,199 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tomz197/asteroids/internal/clock"
+	"github.com/tomz197/asteroids/internal/draw"
+	"github.com/tomz197/asteroids/internal/loop/server"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+func TestEdgeStripes(t *testing.T) {
+	world := object.Screen{Width: 400, Height: 400, CenterX: 200, CenterY: 200}
+	view := object.Screen{Width: 100, Height: 60}
+	vertical := func(x float64) [2]draw.Point { return [2]draw.Point{{X: x, Y: 0.5}, {X: x, Y: 59.5}} }
+	horizontal := func(y float64) [2]draw.Point { return [2]draw.Point{{X: 0.5, Y: y}, {X: 99.5, Y: y}} }
+
+	tests := []struct {
+		name   string
+		px, py float64
+		cam    object.Camera
+		want   [][2]draw.Point
+	}{
+		{"middle", 200, 200, object.Camera{X: 200, Y: 200}, nil},
+		// The left wall is 40 units left of the camera: 10 into the view
+		{"left wall in view", 20, 200, object.Camera{X: 40, Y: 200}, [][2]draw.Point{vertical(10)}},
+		// The left wall is 80 units away, out of view: mark the view side
+		{"left wall out of view", 20, 200, object.Camera{X: 80, Y: 200}, [][2]draw.Point{vertical(0.5)}},
+		{"right wall in view", 385, 200, object.Camera{X: 370, Y: 200}, [][2]draw.Point{vertical(80)}},
+		{"top wall", 200, 10, object.Camera{X: 200, Y: 20}, [][2]draw.Point{horizontal(10)}},
+		{"bottom wall out of view", 200, 390, object.Camera{X: 200, Y: 300}, [][2]draw.Point{horizontal(59.5)}},
+		{"corner", 5, 395, object.Camera{X: 30, Y: 380}, [][2]draw.Point{vertical(20), horizontal(50)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := edgeStripes(tt.px, tt.py, tt.cam, view, world, 30)
+			if len(got) != len(tt.want) {
+				t.Fatalf("edgeStripes = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("stripe %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if got := edgeStripes(5, 5, object.Camera{X: 50, Y: 30}, view, world, 0); got != nil {
+		t.Errorf("margin 0: edgeStripes = %v, want none", got)
+	}
+}
+
+// offscreenWrites replays terminal output on a width x height screen and
+// describes every character written outside it. Cursor positioning (CSI H)
+// is followed, other escape sequences are skipped, and each rune advances
+// the cursor by one cell.
+func offscreenWrites(out []byte, width, height int) []string {
+	var bad []string
+	row, col := 1, 1
+	for i := 0; i < len(out); {
+		switch {
+		case out[i] == 0x1b && i+1 < len(out) && out[i+1] == '[':
+			j := i + 2
+			for j < len(out) && (out[j] < 0x40 || out[j] > 0x7e) {
+				j++
+			}
+			if j < len(out) && out[j] == 'H' {
+				row, col = 1, 1
+				fmt.Sscanf(string(out[i+2:j]), "%d;%d", &row, &col)
+			}
+			i = j + 1
+		case out[i] == 0x1b && i+1 < len(out) && out[i+1] == ']':
+			// OSC, ended by ST (ESC \)
+			end := bytes.Index(out[i:], []byte("\x1b\\"))
+			if end < 0 {
+				return append(bad, "unterminated OSC")
+			}
+			i += end + 2
+		default:
+			r, size := utf8.DecodeRune(out[i:])
+			if row < 1 || row > height || col < 1 || col > width {
+				bad = append(bad, fmt.Sprintf("%q at row %d col %d", r, row, col))
+			}
+			col++
+			i += size
+		}
+	}
+	return bad
+}
+
+func TestPlayingHUDFitsSmallTerminals(t *testing.T) {
+	sizes := [][2]int{
+		{1, 1}, {10, 3}, {20, 5}, {40, 10},
+		{hudCompactWidth - 1, hudCompactHeight - 1},
+		{hudCompactWidth, hudCompactHeight},
+		{80, 24},
+	}
+	for _, size := range sizes {
+		t.Run(fmt.Sprintf("%dx%d", size[0], size[1]), func(t *testing.T) {
+			var out bytes.Buffer
+			c := newTestClient(t, size[0], size[1], &out)
+			c.pingFunc = func() time.Duration { return 42 * time.Millisecond }
+
+			ship := object.NewUser(200, 200)
+			ship.OwnerID = c.handle.ID
+			ship.RapidFireTime, ship.TripleShotTime, ship.PierceTime = 7, 3, 2
+			c.state.GameState = GameStatePlaying
+			c.state.prevGameState = GameStatePlaying
+			c.state.Player = ship
+			c.state.Score = 1234567
+			c.state.Lives = 1
+			c.state.Camera = object.Camera{X: 200, Y: 200}
+			c.snapshot = &server.WorldSnapshot{
+				Objects:     []object.Object{ship, object.NewAsteroid(210, 190, object.AsteroidLarge, 0)},
+				UserObjects: []*object.User{ship},
+				World:       object.Screen{Width: 400, Height: 400, CenterX: 200, CenterY: 200},
+				Players:     12,
+				ChatMessages: []server.ChatMessage{
+					{Username: "someone", Text: "a chat message long enough to wrap", At: time.Unix(0, 0)},
+				},
+				TopScores: []server.TopScoreEntry{
+					{Username: "a-rather-long-name", Score: 9999999, ClientID: 7},
+					{Username: "pilot", Score: 1234567, ClientID: c.handle.ID},
+				},
+			}
+
+			if err := c.drawFrame(); err != nil {
+				t.Fatalf("drawFrame: %v", err)
+			}
+			for _, w := range offscreenWrites(out.Bytes(), size[0], size[1]) {
+				t.Errorf("write outside the %dx%d terminal: %s", size[0], size[1], w)
+			}
+		})
+	}
+}
+
+// stubObject is a world object that counts its draws and fails them with
+// err, if set.
+type stubObject struct {
+	draws int
+	err   error
+}
+
+func (o *stubObject) Update(object.UpdateContext) (bool, error) { return false, nil }
+
+func (o *stubObject) Draw(object.DrawContext) error {
+	o.draws++
+	return o.err
+}
+
+func TestDrawFrameSkipsFailingObject(t *testing.T) {
+	var logged bytes.Buffer
+	prev := log.Writer()
+	log.SetOutput(&logged)
+	t.Cleanup(func() { log.SetOutput(prev) })
+
+	var out bytes.Buffer
+	c := newTestClient(t, 80, 24, &out)
+	failing := &stubObject{err: errors.New("broken")}
+	before, after := &stubObject{}, &stubObject{}
+	c.snapshot = &server.WorldSnapshot{
+		Objects: []object.Object{before, failing, after},
+		World:   object.Screen{Width: 400, Height: 400, CenterX: 200, CenterY: 200},
+	}
+
+	const frames = 5
+	for range frames {
+		if err := c.drawFrame(); err != nil {
+			t.Fatalf("drawFrame: %v", err)
+		}
+	}
+	if before.draws != frames || failing.draws != frames || after.draws != frames {
+		t.Errorf("draws before/failing/after = %d/%d/%d, want %d each", before.draws, failing.draws, after.draws, frames)
+	}
+	if n := strings.Count(logged.String(), "broken"); n != 1 {
+		t.Errorf("logged the draw error %d times in %d frames, want once:\n%s", n, frames, logged.String())
+	}
+	// Info-level logs name the client by ID, never by username
+	if strings.Contains(logged.String(), c.username) {
+		t.Errorf("draw error log %q contains the username %q", logged.String(), c.username)
+	}
+
+	// The next report, once the interval has passed, counts the failures since
+	c.clock.(*clock.Fake).Advance(drawErrorLogInterval)
+	logged.Reset()
+	if err := c.drawFrame(); err != nil {
+		t.Fatalf("drawFrame: %v", err)
+	}
+	if !strings.Contains(logged.String(), fmt.Sprintf("%d since last report", frames)) {
+		t.Errorf("second report = %q, want it to count %d failures", logged.String(), frames)
+	}
+}
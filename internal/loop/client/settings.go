@@ -0,0 +1,179 @@
+package client
+
+import (
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// setting is one row of the settings overlay.
+// value renders the current value; change steps it by dir (-1 or +1).
+type setting struct {
+	name   string
+	value  func(c *Client) string
+	change func(c *Client, dir int)
+}
+
+// settings lists the rows of the settings overlay, top to bottom.
+var settings = []setting{
+	{
+		name:  "Theme",
+		value: func(c *Client) string { return themes[c.state.ThemeIndex].Name },
+		change: func(c *Client, dir int) {
+			n := len(themes)
+			c.state.ThemeIndex = (c.state.ThemeIndex + dir + n) % n
+			c.colors = themes[c.state.ThemeIndex].resolve(c.colorLevel)
+		},
+	},
+	{
+		name:   "CRT scanlines",
+		value:  func(c *Client) string { return onOff(c.canvas.Scanlines()) },
+		change: func(c *Client, _ int) { c.canvas.SetScanlines(!c.canvas.Scanlines()) },
+	},
+	{
+		name:   "ASCII only",
+		value:  func(c *Client) string { return onOff(c.canvas.ASCII()) },
+		change: func(c *Client, _ int) { c.canvas.SetASCII(!c.canvas.ASCII()) },
+	},
+	{
+		name:   "Braille dots",
+		value:  func(c *Client) string { return onOff(c.canvas.Braille()) },
+		change: func(c *Client, _ int) { c.canvas.SetBraille(!c.canvas.Braille()) },
+	},
+	{
+		name:  "Fire mode",
+		value: func(c *Client) string { return c.state.Prefs.FireMode.String() },
+		change: func(c *Client, _ int) {
+			if c.state.Prefs.FireMode == object.FireAuto {
+				c.state.Prefs.FireMode = object.FireTap
+			} else {
+				c.state.Prefs.FireMode = object.FireAuto
+			}
+			c.sendPreferences()
+		},
+	},
+	{
+		name:  "Turn ramp",
+		value: func(c *Client) string { return onOff(!c.state.Prefs.InstantTurn) },
+		change: func(c *Client, _ int) {
+			c.state.Prefs.InstantTurn = !c.state.Prefs.InstantTurn
+			c.sendPreferences()
+		},
+	},
+	{
+		name:   "Solid asteroids",
+		value:  func(c *Client) string { return onOff(c.state.FilledAsteroids) },
+		change: func(c *Client, _ int) { c.state.FilledAsteroids = !c.state.FilledAsteroids },
+	},
+	{
+		name:   "Aim reticle",
+		value:  func(c *Client) string { return onOff(c.state.Reticle) },
+		change: func(c *Client, _ int) { c.state.Reticle = !c.state.Reticle },
+	},
+	{
+		name:   "Player names",
+		value:  func(c *Client) string { return onOff(!c.state.HideNames) },
+		change: func(c *Client, _ int) { c.state.HideNames = !c.state.HideNames },
+	},
+	{
+		name:   "Depth dimming",
+		value:  func(c *Client) string { return onOff(c.state.DepthDim) },
+		change: func(c *Client, _ int) { c.state.DepthDim = !c.state.DepthDim },
+	},
+	{
+		name:   "Last-life blink",
+		value:  func(c *Client) string { return onOff(!c.state.NoWarningBlink) },
+		change: func(c *Client, _ int) { c.state.NoWarningBlink = !c.state.NoWarningBlink },
+	},
+}
+
+// onOff formats a boolean setting value.
+func onOff(v bool) string {
+	if v {
+		return "On"
+	}
+	return "Off"
+}
+
+// sendPreferences hands the control preferences to the server, which
+// applies them to this client's ships.
+func (c *Client) sendPreferences() {
+	c.server.SetPreferences(c.handle.ID, c.state.Prefs)
+}
+
+// openSettings shows the settings overlay. While playing, an empty input is
+// sent so the ship doesn't keep thrusting or firing with the last held keys.
+func (c *Client) openSettings() {
+	c.state.SettingsOpen = true
+	if c.state.GameState == GameStatePlaying {
+		c.server.SendInput(c.handle.ID, object.Input{Number: -1})
+	}
+}
+
+// updateSettings handles input while the settings overlay is open.
+// Keys are edge-triggered so a held key moves or changes one step.
+func (c *Client) updateSettings() {
+	in, prev := c.state.Input, c.state.prevInput
+	if in.Escape || in.Settings || in.Quit {
+		c.state.SettingsOpen = false
+		return
+	}
+
+	n := len(settings)
+	if in.Up && !prev.Up {
+		c.state.settingsCursor = (c.state.settingsCursor + n - 1) % n
+	}
+	if in.Down && !prev.Down {
+		c.state.settingsCursor = (c.state.settingsCursor + 1) % n
+	}
+
+	row := settings[c.state.settingsCursor]
+	switch {
+	case in.Left && !prev.Left:
+		row.change(c, -1)
+	case (in.Right && !prev.Right) || in.Space || in.Enter:
+		row.change(c, 1)
+	}
+}
+
+// settingsWidth is the fixed width of the settings overlay; every line is
+// padded to it so the overlay fully covers the game underneath.
+const settingsWidth = 36
+
+// drawSettings draws the settings overlay centered on the screen.
+func (c *Client) drawSettings(centerX, centerY int) {
+	cw := c.chunkWriter
+	col := centerX - settingsWidth/2
+	row := centerY - (len(settings)+4)/2
+
+	line := func(r int, color string, text []byte) {
+		for textWidth(string(text)) < settingsWidth {
+			text = append(text, ' ')
+		}
+		cw.WriteColoredAt(col, r, color, string(text))
+		c.hudBuf = text
+	}
+
+	line(row, c.colors.title, append(c.hudBuf[:0], "  SETTINGS"...))
+	line(row+1, c.colors.hud, c.hudBuf[:0])
+	for i, s := range settings {
+		b := c.hudBuf[:0]
+		if i == c.state.settingsCursor {
+			b = append(b, "> "...)
+		} else {
+			b = append(b, "  "...)
+		}
+		b = append(b, s.name...)
+		for len(b) < 18 {
+			b = append(b, ' ')
+		}
+		b = append(b, "< "...)
+		b = append(b, s.value(c)...)
+		b = append(b, " >"...)
+		color := c.colors.hud
+		if i == c.state.settingsCursor {
+			color = c.colors.warning
+		}
+		line(row+2+i, color, b)
+	}
+	line(row+2+len(settings), c.colors.hud, c.hudBuf[:0])
+	line(row+3+len(settings), c.colors.hud, append(c.hudBuf[:0], "  W/S select  A/D change  M close"...))
+}
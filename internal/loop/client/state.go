@@ -1,11 +1,11 @@
 package client
 
 import (
-	"strings"
 	"time"
 
 	"github.com/tomz197/asteroids/internal/draw"
 	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/loop/server"
 	"github.com/tomz197/asteroids/internal/object"
 )
 
@@ -13,54 +13,76 @@ import (
 type GameState int
 
 const (
-	GameStateStart    GameState = iota // Title screen
-	GameStatePlaying                   // Active gameplay
-	GameStateDead                      // Player died, show restart prompt
-	GameStateShutdown                  // Server is shutting down
+	GameStateStart     GameState = iota // Title screen
+	GameStatePlaying                    // Active gameplay
+	GameStateDead                       // Player died, show restart prompt
+	GameStateShutdown                   // Server is shutting down
+	GameStateSummary                    // Session summary shown before quitting
+	GameStateNameEntry                  // Player types a name; shown before the title screen when none was given
 )
 
-// Minimap dimensions (inner grid, excluding border).
-// minimapSubRows is 2x minimapHeight for half-block vertical resolution.
+// Below either size the playing HUD switches to its compact layout: short
+// labels, and no leaderboard, minimap, coordinates or heading.
 const (
-	minimapWidth   = 20
-	minimapHeight  = 10 // Terminal rows
-	minimapSubRows = 20 // Logical sub-rows (2 per terminal row)
-)
-
-// Pre-computed minimap border strings (avoids per-frame strings.Repeat allocation).
-var (
-	minimapTopBorder    = "┌" + strings.Repeat("─", minimapWidth) + "┐"
-	minimapBottomBorder = "└" + strings.Repeat("─", minimapWidth) + "┘"
+	hudCompactWidth  = 60
+	hudCompactHeight = 20
 )
 
 // ClientState holds per-player state (input, score, camera, etc.).
 // Each client has their own instance, managed by the Client.
 type ClientState struct {
-	// minimapGrid is a reusable buffer for minimap rendering (avoids per-frame allocation).
-	// Uses 2x vertical resolution for half-block rendering.
-	minimapGrid          [minimapSubRows][minimapWidth]byte
 	Input                object.Input
-	View                 object.Screen     // Viewport dimensions (can vary per client)
-	Camera               object.Camera     // Camera position (follows this client's player)
-	GameState            GameState         // This client's game phase
-	prevGameState        GameState         // Previous frame's game state (for transition detection)
-	Player               *object.User      // Reference to this client's ship (from server)
-	Score                int               // This client's score
-	Lives                int               // This client's remaining lives
-	InvincibleTime       float64           // Remaining invincibility time in seconds
-	RespawnTimeRemaining float64           // Seconds until respawn is allowed (set on death)
-	KilledBy             string            // Username of player who killed this one (empty if asteroid)
-	termSizeFunc         draw.TermSizeFunc // Function to get terminal size
-	Running              bool              // Client loop running
-	delta                time.Duration     // Frame delta time (client-side)
-	shutdownTimer        float64           // Countdown before auto-disconnect on shutdown
-	isInactive           bool              // Whether the client is in inactive warning state
-	wasInactive          bool              // Previous frame's inactivity state (for transition detection)
-	ChatOpen             bool              // Whether chat input box is active
-	ChatInput            string            // Current message being typed
-	prevChatOpen         bool              // Previous frame's chat state (for transition detection)
-	cachedChatLines      []string          // Cached wrapped chat lines (invalidated on message count change)
-	cachedChatMsgCount   int               // Message count when cache was built
+	prevInput            object.Input        // Previous frame's input (for edge-triggered menu keys)
+	View                 object.Screen       // Viewport dimensions (can vary per client)
+	Camera               object.Camera       // Camera position (follows this client's player)
+	GameState            GameState           // This client's game phase
+	prevGameState        GameState           // Previous frame's game state (for transition detection)
+	Player               *object.User        // Reference to this client's ship (from server)
+	Score                int                 // This client's score
+	Lives                int                 // This client's remaining lives
+	InvincibleTime       float64             // Remaining invincibility time in seconds
+	RespawnTimeRemaining float64             // Seconds until respawn is allowed (set on death)
+	deathCamTime         float64             // Seconds left watching our own explosion before the dead screen
+	KilledBy             string              // Username of player who killed this one (empty if asteroid)
+	Stats                server.PlayerStats  // Shooting stats as of the last death
+	Summary              server.SessionStats // Session summary shown in GameStateSummary
+	summaryReturnState   GameState           // State to return to if the player cancels quitting
+	termSizeFunc         draw.TermSizeFunc   // Function to get terminal size
+	Running              bool                // Client loop running
+	delta                time.Duration       // Frame delta time (client-side)
+	shutdownTimer        float64             // Countdown before auto-disconnect on shutdown
+	isInactive           bool                // Whether the client is in inactive warning state
+	wasInactive          bool                // Previous frame's inactivity state (for transition detection)
+	ChatOpen             bool                // Whether chat input box is active
+	ChatInput            string              // Current message being typed
+	NameInput            string              // Name being typed in GameStateNameEntry
+	prevChatOpen         bool                // Previous frame's chat state (for transition detection)
+	cachedChatLines      []string            // Cached wrapped chat lines (invalidated when the shown messages change)
+	cachedChatLineMsg    []int               // Index into the snapshot's chat messages of each cached line
+	cachedChatMsgCount   int                 // Message count when cache was built
+	cachedChatFirst      int                 // Index of the oldest shown message when cache was built
+	cachedChatNewest     time.Time           // Newest message's time when cache was built
+	ThemeIndex           int                 // Index into themes of the active UI theme
+	Toast                string              // Transient notification text (e.g. achievement unlocked)
+	Ship                 object.ShipShape    // Selected ship silhouette, sent to the server on spawn
+	toastTime            float64             // Seconds the toast remains visible
+	waveBanner           int                 // Wave announced by the "WAVE N" banner (0 = none)
+	waveBannerTime       float64             // Seconds the wave banner remains visible
+	SettingsOpen         bool                // Whether the settings overlay is shown
+	prevSettingsOpen     bool                // Previous frame's settings state (for transition detection)
+	settingsCursor       int                 // Selected row in the settings overlay
+	Reticle              bool                // Draw an aim reticle ahead of the ship
+	Prefs                server.Preferences  // Control preferences, applied by the server (see sendPreferences)
+	FilledAsteroids      bool                // Draw asteroids filled instead of outlined
+	HideNames            bool                // Hide the name labels above other players' ships
+	HideLeaderboard      bool                // Hide the leaderboard panel while playing (toggled with Tab)
+	DepthDim             bool                // Dim other players' ships near the view edges
+	NoWarningBlink       bool                // Keep the last-life lives counter steady instead of blinking
+	PersonalBest         int                 // Best game score for this username, including this session
+	NewPersonalBest      bool                // The last game over beat PersonalBest
+	HelpOpen             bool                // Whether the controls help overlay is shown
+	prevHelpOpen         bool                // Previous frame's help state (for transition detection)
+	Paused               bool                // World paused behind the help overlay (pausable clients only)
 }
 
 // NewClientState creates a new initialized client state.
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/tomz197/asteroids/internal/draw"
+)
+
+// ThemeColor is a single theme entry. Basic is an SGR sequence from the 16-color
+// set; Rich is an optional 256-color refinement ("" falls back to Basic).
+type ThemeColor struct {
+	Basic string
+	Rich  string
+}
+
+// Theme maps semantic UI roles to colors.
+type Theme struct {
+	Name    string
+	Title   ThemeColor // ASCII art titles and section headers
+	HUD     ThemeColor // Score, lives, and other readouts
+	Warning ThemeColor // Prompts, alerts, and death/shutdown notices
+	Self    ThemeColor // The local player's minimap marker
+	Enemy   ThemeColor // Other players' names and minimap markers
+
+	// Protected colors spawn-protected (invulnerable) asteroids. Empty, or a
+	// terminal without color, keeps the protection blink instead.
+	Protected ThemeColor
+
+	// Ships colors other players' ships and names, one entry per ship color
+	// slot, cycled when shorter. Empty draws them all in Enemy: the
+	// one-color themes keep their look, and the colorblind themes keep
+	// other players apart from Self and Warning.
+	Ships []ThemeColor
+
+	// SelfMarker is the minimap glyph for the local player, so identity
+	// doesn't rest on color alone; 0 draws blocks like everyone else.
+	SelfMarker rune
+}
+
+// themes lists the built-in themes. The first entry is the default and keeps
+// the original look: default-colored text with a bright cyan self marker
+// (plus a dim blue tint for protected asteroids).
+var themes = []Theme{
+	{
+		Name:      "default",
+		Self:      ThemeColor{Basic: draw.ColorBrightCyan},
+		Protected: ThemeColor{Basic: draw.ColorBlue, Rich: draw.Color256(67)},
+		Ships:     defaultShipPalette,
+	},
+	{
+		Name:      "classic",
+		Title:     ThemeColor{Basic: draw.ColorBrightGreen},
+		HUD:       ThemeColor{Basic: draw.ColorGreen},
+		Warning:   ThemeColor{Basic: draw.ColorBrightYellow},
+		Self:      ThemeColor{Basic: draw.ColorBrightGreen},
+		Enemy:     ThemeColor{Basic: draw.ColorGreen},
+		Protected: ThemeColor{Basic: draw.ColorCyan, Rich: draw.Color256(30)},
+	},
+	{
+		Name:      "amber",
+		Title:     ThemeColor{Basic: draw.ColorBrightYellow, Rich: draw.Color256(214)},
+		HUD:       ThemeColor{Basic: draw.ColorYellow, Rich: draw.Color256(172)},
+		Warning:   ThemeColor{Basic: draw.ColorBrightRed, Rich: draw.Color256(202)},
+		Self:      ThemeColor{Basic: draw.ColorBrightYellow, Rich: draw.Color256(220)},
+		Enemy:     ThemeColor{Basic: draw.ColorYellow, Rich: draw.Color256(136)},
+		Protected: ThemeColor{Basic: draw.ColorBlue, Rich: draw.Color256(60)},
+	},
+	{
+		Name:      "high-contrast",
+		Title:     ThemeColor{Basic: draw.ColorBrightWhite},
+		HUD:       ThemeColor{Basic: draw.ColorBrightWhite},
+		Warning:   ThemeColor{Basic: draw.ColorBrightRed},
+		Self:      ThemeColor{Basic: draw.ColorBrightCyan},
+		Enemy:     ThemeColor{Basic: draw.ColorBrightYellow},
+		Protected: ThemeColor{Basic: draw.ColorBrightBlue},
+		Ships:     highContrastShipPalette,
+	},
+	// Color-vision-deficiency palettes: roles are kept apart by hue pairs
+	// each condition still distinguishes (blue/orange for red-green,
+	// red/cyan for blue-yellow), and the self marker is a distinct glyph.
+	{
+		Name:       "deuteranopia",
+		Title:      ThemeColor{Basic: draw.ColorBrightBlue, Rich: draw.Color256(33)},
+		HUD:        ThemeColor{Basic: draw.ColorWhite, Rich: draw.Color256(252)},
+		Warning:    ThemeColor{Basic: draw.ColorBrightYellow, Rich: draw.Color256(214)},
+		Self:       ThemeColor{Basic: draw.ColorBrightCyan, Rich: draw.Color256(39)},
+		Enemy:      ThemeColor{Basic: draw.ColorYellow, Rich: draw.Color256(208)},
+		Protected:  ThemeColor{Basic: draw.ColorBlue, Rich: draw.Color256(61)},
+		SelfMarker: '@',
+	},
+	{
+		Name:       "protanopia",
+		Title:      ThemeColor{Basic: draw.ColorBrightCyan, Rich: draw.Color256(38)},
+		HUD:        ThemeColor{Basic: draw.ColorWhite, Rich: draw.Color256(252)},
+		Warning:    ThemeColor{Basic: draw.ColorBrightYellow, Rich: draw.Color256(226)},
+		Self:       ThemeColor{Basic: draw.ColorBrightBlue, Rich: draw.Color256(33)},
+		Enemy:      ThemeColor{Basic: draw.ColorYellow, Rich: draw.Color256(178)},
+		Protected:  ThemeColor{Basic: draw.ColorBlue, Rich: draw.Color256(60)},
+		SelfMarker: '@',
+	},
+	{
+		Name:       "tritanopia",
+		Title:      ThemeColor{Basic: draw.ColorBrightWhite, Rich: draw.Color256(255)},
+		HUD:        ThemeColor{Basic: draw.ColorWhite, Rich: draw.Color256(252)},
+		Warning:    ThemeColor{Basic: draw.ColorBrightMagenta, Rich: draw.Color256(199)},
+		Self:       ThemeColor{Basic: draw.ColorBrightCyan, Rich: draw.Color256(51)},
+		Enemy:      ThemeColor{Basic: draw.ColorBrightRed, Rich: draw.Color256(203)},
+		Protected:  ThemeColor{Basic: draw.ColorBrightBlack, Rich: draw.Color256(245)},
+		SelfMarker: '@',
+	},
+}
+
+// defaultShipPalette colors other players' ships in the default theme. It
+// leaves out cyan, which the theme uses for the local player.
+var defaultShipPalette = []ThemeColor{
+	{Basic: draw.ColorBrightRed, Rich: draw.Color256(203)},
+	{Basic: draw.ColorBrightGreen, Rich: draw.Color256(120)},
+	{Basic: draw.ColorBrightYellow, Rich: draw.Color256(221)},
+	{Basic: draw.ColorBrightMagenta, Rich: draw.Color256(213)},
+	{Basic: draw.ColorBrightBlue, Rich: draw.Color256(75)},
+	{Basic: draw.ColorYellow, Rich: draw.Color256(208)},
+}
+
+// highContrastShipPalette colors other players' ships in the high-contrast
+// theme: bright, saturated colors other than its cyan Self and red Warning.
+var highContrastShipPalette = []ThemeColor{
+	{Basic: draw.ColorBrightYellow},
+	{Basic: draw.ColorBrightMagenta},
+	{Basic: draw.ColorBrightGreen},
+	{Basic: draw.ColorBrightWhite},
+	{Basic: draw.ColorBrightBlue},
+}
+
+// ThemeByName returns the index of the built-in theme with the given name
+// (case-insensitive). Returns 0 (the default theme) and false if none matches.
+func ThemeByName(name string) (int, bool) {
+	for i, t := range themes {
+		if strings.EqualFold(t.Name, name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// uiColors holds a theme's SGR sequences resolved for a terminal's color level.
+// Empty strings mean "write uncolored".
+type uiColors struct {
+	title     string
+	hud       string
+	warning   string
+	self      string
+	enemy     string
+	protected string
+
+	ships    []string // Other players' ships by color slot (see Theme.Ships); nil without color
+	selfShip []string // The local ship's color as a one-slot palette; nil without color
+
+	selfMarker rune // Minimap glyph for the local player (0 = blocks)
+}
+
+// resolve picks the sequences the terminal can display: none without color
+// support, Basic on 16-color terminals, and Rich (when set) on richer ones.
+func (t Theme) resolve(level draw.ColorLevel) uiColors {
+	pick := func(c ThemeColor) string {
+		switch {
+		case level == draw.ColorLevelNone:
+			return ""
+		case level >= draw.ColorLevel256 && c.Rich != "":
+			return c.Rich
+		default:
+			return c.Basic
+		}
+	}
+	colors := uiColors{
+		title:     pick(t.Title),
+		hud:       pick(t.HUD),
+		warning:   pick(t.Warning),
+		self:      pick(t.Self),
+		enemy:     pick(t.Enemy),
+		protected: pick(t.Protected),
+
+		selfMarker: t.SelfMarker,
+	}
+	switch {
+	case level == draw.ColorLevelNone:
+	case len(t.Ships) > 0:
+		colors.ships = make([]string, len(t.Ships))
+		for i, c := range t.Ships {
+			colors.ships[i] = pick(c)
+		}
+	case colors.enemy != "":
+		colors.ships = []string{colors.enemy}
+	}
+	if colors.self != "" {
+		colors.selfShip = []string{colors.self}
+	}
+	return colors
+}
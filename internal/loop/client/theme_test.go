@@ -0,0 +1,62 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/tomz197/asteroids/internal/draw"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// TestThemeShipColors checks that other players' ships never share the
+// local player's or the warning color, in every theme and color level.
+func TestThemeShipColors(t *testing.T) {
+	for _, theme := range themes {
+		for _, level := range []draw.ColorLevel{draw.ColorLevel16, draw.ColorLevel256, draw.ColorLevelTrue} {
+			colors := theme.resolve(level)
+			for i, ship := range colors.ships {
+				if ship == "" {
+					continue
+				}
+				if ship == colors.self {
+					t.Errorf("%s (level %d): ship slot %d uses the Self color", theme.Name, level, i)
+				}
+				if ship == colors.warning {
+					t.Errorf("%s (level %d): ship slot %d uses the Warning color", theme.Name, level, i)
+				}
+			}
+		}
+		if colors := theme.resolve(draw.ColorLevelNone); colors.ships != nil {
+			t.Errorf("%s: ship colors %q without color support, want none", theme.Name, colors.ships)
+		}
+	}
+}
+
+// TestColorblindThemesDrawShipsInEnemy keeps the colorblind themes to the
+// hues they were designed around: every other ship is the Enemy color.
+func TestColorblindThemesDrawShipsInEnemy(t *testing.T) {
+	for _, name := range []string{"deuteranopia", "protanopia", "tritanopia"} {
+		i, ok := ThemeByName(name)
+		if !ok {
+			t.Fatalf("no %s theme", name)
+		}
+		for _, level := range []draw.ColorLevel{draw.ColorLevel16, draw.ColorLevel256} {
+			colors := themes[i].resolve(level)
+			if len(colors.ships) != 1 || colors.ships[0] != colors.enemy {
+				t.Errorf("%s (level %d): ship colors %q, want just Enemy %q", name, level, colors.ships, colors.enemy)
+			}
+		}
+	}
+}
+
+func TestDefaultShipPaletteCoversEverySlot(t *testing.T) {
+	if len(defaultShipPalette) != object.ShipColorCount {
+		t.Fatalf("default palette has %d colors, want one per slot (%d)", len(defaultShipPalette), object.ShipColorCount)
+	}
+	seen := make(map[string]bool)
+	for _, c := range defaultShipPalette {
+		if seen[c.Rich] {
+			t.Errorf("default palette repeats %q", c.Rich)
+		}
+		seen[c.Rich] = true
+	}
+}
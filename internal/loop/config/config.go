@@ -1,7 +1,11 @@
 // Package config centralizes all tunable game parameters.
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/tomz197/asteroids/internal/object"
+)
 
 // View resolution - the visible viewport in logical units.
 // Actual rendering scales to fit terminal size.
@@ -19,25 +23,71 @@ const (
 
 // Scoring
 const (
+	ScoreHugeAsteroid   = 150 // Only with a world file's "huge_asteroids" chance set
 	ScoreLargeAsteroid  = 20
 	ScoreMediumAsteroid = 50
 	ScoreSmallAsteroid  = 100
 	ScorePlayerKill     = 1000
 	TopScoresCount      = 5 // Number of top scores to track and display
+	AllTimeScoresCount  = 5 // Number of all-time high scores kept (see SCORES_FILE)
+
+	// Assists: with a world file's "assists" share set, players who hit an
+	// asteroid (or the asteroid it split from) within AssistWindow get that
+	// share of its score when someone else destroys it.
+	AssistWindow = 3 * time.Second
 )
 
 // Player
 const (
 	InitialLives         = 3
 	InvincibilityTime    = 3 * time.Second
+	InvincibilityFade    = 1 * time.Second // Ship fades from bright to normal over the end of invincibility (256-color terminals)
 	RespawnTimeout       = 3 * time.Second
-	PlayerBlinkFrequency = 10.0 // Hz
-	MaxUsernameLength    = 16   // Maximum display length for player usernames
+	DeathCamTime         = 1 * time.Second // How long the camera lingers on your explosion before the dead screen (0 = off)
+	KillCamTime          = 2 * time.Second // Seconds before a death replayed after the death cam (0 = off)
+	KillCamSpeed         = 0.5             // Kill cam playback speed (0.5 = half speed)
+	PlayerBlinkFrequency = 10.0            // Hz
+	MaxUsernameLength    = 16              // Maximum display length for player usernames
+	ProjectilePierce     = 0               // Small asteroids each shot passes through before it is spent (0 = single hit; keep to 1-2)
+	HyperspaceInvincible = 1 * time.Second // Invincibility after a safe hyperspace jump, while the ship re-materializes
+)
+
+// Achievements
+const (
+	SharpshooterAccuracy = 50.0            // Minimum accuracy percentage for Sharpshooter
+	SharpshooterMinShots = 50              // Shots fired before accuracy counts toward Sharpshooter
+	SurvivorTime         = 5 * time.Minute // Time alive on one ship for Survivor
+	AsteroidHunterCount  = 100             // Asteroids destroyed in a session for Asteroid Hunter
 )
 
 // Spawning
 const (
 	InitialAsteroidTarget = 250
+	AsteroidRampRate      = 10.0 // Weighted asteroids per second the population moves from a world's initial_asteroids toward its target
+	AsteroidRefillRate    = 24.0 // Weighted asteroids per second spawned at most to replace destroyed ones (6 large/s)
+	SpawnClearance        = 15.0 // Free distance wanted around a new ship (to asteroid edges and ships)
+	SpawnAttempts         = 16   // Candidate positions sampled per spawn
+	RespawnNearRadius     = 60.0 // Respawn within this distance of the last death in worlds with respawn_near_death
+)
+
+// Waves, for worlds with "waves": true in WORLD_FILE: each wave spawns its
+// asteroids once, and the next starts once they are all destroyed. Counts
+// are weighted (a large asteroid is 4).
+const (
+	WaveBaseAsteroids = 40              // Asteroids in wave 1 (10 large)
+	WaveAsteroidStep  = 16              // Asteroids added per wave (4 large)
+	WaveSpeedStep     = 0.1             // Asteroid speed added per wave, as a fraction of normal (wave 3 = 1.2x)
+	WaveMaxSpeedScale = 2.0             // Cap on the wave speed multiplier
+	WaveBreak         = 3 * time.Second // Pause between clearing a wave and the next one
+	WaveBannerTime    = 2 * time.Second // How long clients flash "WAVE N" when a wave begins
+)
+
+// Power-ups
+const (
+	PowerUpInterval = 15 * time.Second // How often a pickup spawns while fewer than MaxPowerUps are out (0 = no power-ups)
+	MaxPowerUps     = 3                // Pickups in the world at once
+	PowerUpLifetime = 30 * time.Second // How long an uncollected pickup stays
+	PowerUpDuration = 10 * time.Second // How long a collected weapon power-up lasts
 )
 
 // Shutdown
@@ -49,12 +99,23 @@ const (
 const (
 	InactivityWarnUser       = 90  // Seconds
 	InactivityDisconnectUser = 120 // Seconds
+
+	// While playing, a ship moving at least this fast or a score within
+	// IdleScoreWindow counts as activity, so coasting players aren't flagged
+	// idle. 0 disables the respective check.
+	IdleMotionSpeed = 5.0 // World units per second
+	IdleScoreWindow = 30 * time.Second
 )
 
 // Chat
 const (
 	MaxChatMessageLength = 128 // Maximum characters per chat message
 	MaxChatHistory       = 50  // Messages kept in server buffer
+
+	// While chat is closed, messages leave the log ChatFadeTime after they
+	// were sent, drawn dimmed for the last ChatFadeOutTime of it.
+	ChatFadeTime    = 20 * time.Second
+	ChatFadeOutTime = 5 * time.Second
 )
 
 // Maximum terminal render resolution.
@@ -64,14 +125,90 @@ const (
 	MaxTermHeight = 80  // Maximum terminal rows for rendering
 )
 
+// Fallback terminal size, used until a valid (non-zero) size is reported.
+const (
+	DefaultTermWidth  = 80
+	DefaultTermHeight = 24
+)
+
 // Client rendering
 const (
 	ClientTargetFPS       = 60
 	ClientTargetFrameTime = time.Second / ClientTargetFPS
+	ClientMinFPS          = 10                     // Lowest frame rate the local game accepts (FPS)
+	ClientMaxFPS          = 120                    // Highest frame rate the local game accepts (FPS)
+	ToastDisplayTime      = 3 * time.Second        // How long notifications like achievements stay on screen
+	PingWarnThreshold     = 200 * time.Millisecond // Round-trip times from here on are shown in the warning color
+)
+
+// Aim reticle: a dotted line ahead of the ship (distances in logical units)
+const (
+	ReticleStart    = 10.0 // Distance of the first dot from the ship center
+	ReticleSpacing  = 4.0  // Distance between dots
+	ReticleDotCount = 3
+)
+
+// Edge warning: in a bounded world, a dashed stripe marks a world edge the
+// player is closing in on, or the side of the view facing it when the edge
+// is out of view (world units)
+const (
+	EdgeWarningMargin = 30.0 // Distance from an edge at which the stripe appears; 0 disables it
+	EdgeWarningDash   = 3.0  // Length of each dash, in logical units
+)
+
+// Explosions are the particle bursts for each kind of explosion. The server
+// scales all counts by the PARTICLE_SCALE env var (0 disables particles).
+var Explosions = object.ExplosionConfig{
+	Asteroid:    object.ParticleBurst{Count: 4, Speed: 20.0, Lifetime: 0.5},
+	ShipDeath:   object.ParticleBurst{Count: 20, Speed: 25.0, Lifetime: 1.0},
+	Impact:      object.ParticleBurst{Count: 4, Speed: 30.0, Lifetime: 0.15},
+	MuzzleFlash: object.ParticleBurst{Count: 2, Speed: 15.0, Lifetime: 0.05},
+	Hyperspace:  object.ParticleBurst{Count: 10, Speed: 12.0, Lifetime: 0.4},
+}
+
+// Hyperspace lets a ship jump to a random spot (H key) every 5 seconds. One
+// jump in ten lands inside an asteroid, as in the arcade original; set Risk
+// to 0 for always-safe jumps.
+var Hyperspace = object.HyperspaceConfig{Cooldown: 5.0, Risk: 0.1}
+
+// TurnRamp makes a tapped turn key nudge the ship (40% turn speed) and
+// ramps to full speed over 0.3s of holding. Grace bridges the gaps between
+// terminal key repeats; it must exceed the key hold window (30ms). Set
+// Start to 1 for the old constant-speed turning.
+var TurnRamp = object.TurnRamp{Start: 0.4, Time: 0.3, Exponent: 1.5, Grace: 0.1}
+
+// AsteroidSplit is how destroyed asteroids break into the next size down.
+// The default is 2 fragments in random directions; e.g. {Count: 3,
+// Spread: math.Pi / 2} fans three ahead, {Count: 2, Spread: math.Pi} sends
+// two off to either side. The weighted asteroid target scales with the
+// pattern (a large asteroid counts as Count² smalls).
+var AsteroidSplit = object.SplitPattern{Count: 2, Spread: 0}
+
+// Death explosion budget. When many ships die in the same tick the
+// ShipDeath burst is shrunk so the tick stays bounded; deaths themselves
+// are never skipped.
+const (
+	DeathParticleBudget = 120 // Max death-explosion particles spawned per tick
+	MinDeathParticles   = 3   // Floor per explosion so every death stays visible
 )
 
 // Server tick rate
 const (
 	ServerTickRate = 60
 	ServerTickTime = time.Second / ServerTickRate
+
+	// MaxTickDelta caps the simulated time per tick, so a stalled tick
+	// (GC pause, overloaded host) slows the game briefly instead of letting
+	// objects jump or tunnel through each other.
+	MaxTickDelta = 100 * time.Millisecond
+)
+
+// Interest management (INTEREST_MANAGEMENT): each client's snapshot holds
+// only the objects around its camera and its ship (the view plus
+// InterestMargin on every side) and every ship, found with a spatial grid
+// of InterestCellSize cells. The margin covers camera movement since the
+// client last reported it and objects entering the view.
+const (
+	InterestMargin   = 30.0 // World units beyond each view edge
+	InterestCellSize = 20.0
 )
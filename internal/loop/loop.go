@@ -24,10 +24,14 @@ func RunClientServer(r *bufio.Reader, w io.Writer, opts client.ClientOptions) er
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	srv := server.NewServer()
+	srv, err := server.NewServer()
+	if err != nil {
+		return err
+	}
 	go srv.Run(ctx)
 
-	// Create and run client
+	// Create and run client; the server is private, so it may pause it
+	opts.Pausable = true
 	c := client.NewClient(srv, r, w, opts)
 	return c.Run()
 }
@@ -0,0 +1,36 @@
+// Package pacing keeps fixed-rate loops on a steady cadence.
+package pacing
+
+import "time"
+
+// Pacer schedules loop iterations on absolute deadlines (start + n*interval)
+// rather than sleeping "interval minus elapsed" after each one. A slow
+// iteration is absorbed by shorter sleeps afterwards instead of shifting
+// every later frame, and sleep overshoot doesn't accumulate as drift.
+type Pacer struct {
+	interval time.Duration
+	next     time.Time
+}
+
+// New returns a pacer for the given iteration interval.
+func New(interval time.Duration) *Pacer {
+	return &Pacer{interval: interval}
+}
+
+// Wait blocks until the next deadline. If the loop has fallen more than a
+// full interval behind, the missed deadlines are skipped and the cadence
+// restarts from now, so a stall isn't followed by a burst of catch-up frames.
+func (p *Pacer) Wait() {
+	now := time.Now()
+	if p.next.IsZero() {
+		p.next = now
+	}
+	p.next = p.next.Add(p.interval)
+	if d := p.next.Sub(now); d > 0 {
+		time.Sleep(d)
+		return
+	}
+	if now.Sub(p.next) > p.interval {
+		p.next = now
+	}
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"log"
+	"sync"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+)
+
+// Achievement is a milestone a player unlocks once per username.
+type Achievement struct {
+	ID          string // Stable identifier used for persistence; never rename
+	Name        string // Display name shown in the unlock toast
+	Description string
+	// Reached reports whether the given progress satisfies the achievement.
+	Reached func(p AchievementProgress) bool
+}
+
+// AchievementProgress holds the counters achievements are evaluated against.
+type AchievementProgress struct {
+	Kills              int         // Players destroyed this session
+	AsteroidsDestroyed int         // Asteroids destroyed this session
+	Stats              PlayerStats // Shooting stats this session
+	AliveTime          float64     // Seconds the current ship has survived
+}
+
+// achievements lists every achievement. Add new entries here; unlock checks,
+// persistence, and client notifications pick them up automatically.
+var achievements = []Achievement{
+	{
+		ID:          "first_blood",
+		Name:        "First Blood",
+		Description: "Destroy another player's ship",
+		Reached:     func(p AchievementProgress) bool { return p.Kills >= 1 },
+	},
+	{
+		ID:          "sharpshooter",
+		Name:        "Sharpshooter",
+		Description: "Keep a high accuracy over many shots",
+		Reached: func(p AchievementProgress) bool {
+			return p.Stats.ShotsFired >= config.SharpshooterMinShots &&
+				p.Stats.Accuracy() >= config.SharpshooterAccuracy
+		},
+	},
+	{
+		ID:          "survivor",
+		Name:        "Survivor",
+		Description: "Stay alive for a long time",
+		Reached: func(p AchievementProgress) bool {
+			return p.AliveTime >= config.SurvivorTime.Seconds()
+		},
+	},
+	{
+		ID:          "asteroid_hunter",
+		Name:        "Asteroid Hunter",
+		Description: "Destroy a large number of asteroids",
+		Reached: func(p AchievementProgress) bool {
+			return p.AsteroidsDestroyed >= config.AsteroidHunterCount
+		},
+	},
+}
+
+// progress returns the handle's current achievement progress.
+func (h *ClientHandle) progress() AchievementProgress {
+	return AchievementProgress{
+		Kills:              h.Kills,
+		AsteroidsDestroyed: h.AsteroidsDestroyed,
+		Stats:              h.Stats,
+		AliveTime:          h.AliveTime,
+	}
+}
+
+// achievementStore keeps unlocked achievement IDs per username and optionally
+// persists them to a JSON file. Safe for concurrent use.
+type achievementStore struct {
+	mu       sync.Mutex
+	path     string                     // JSON file path; "" keeps achievements in memory only
+	unlocked map[string]map[string]bool // username -> achievement ID -> unlocked
+	saveMu   sync.Mutex                 // Serializes file writes
+}
+
+// newAchievementStore creates a store backed by the file at path, loading any
+// previously saved achievements. A missing or corrupt file starts empty.
+func newAchievementStore(path string) *achievementStore {
+	st := &achievementStore{
+		path:     path,
+		unlocked: make(map[string]map[string]bool),
+	}
+	if path == "" {
+		return st
+	}
+	var saved map[string][]string
+	if err := loadJSONFile(path, &saved); err != nil {
+		log.Printf("Achievements: %v; starting empty", err)
+		return st
+	}
+	for user, ids := range saved {
+		set := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			set[id] = true
+		}
+		st.unlocked[user] = set
+	}
+	return st
+}
+
+// forUser returns a copy of the achievements unlocked by username.
+func (st *achievementStore) forUser(username string) map[string]bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	set := make(map[string]bool, len(st.unlocked[username]))
+	for id := range st.unlocked[username] {
+		set[id] = true
+	}
+	return set
+}
+
+// unlock records an achievement for username and saves the store in the
+// background. Anonymous players are not persisted.
+func (st *achievementStore) unlock(username, id string) {
+	if username == "" {
+		return
+	}
+	st.mu.Lock()
+	set, ok := st.unlocked[username]
+	if !ok {
+		set = make(map[string]bool)
+		st.unlocked[username] = set
+	}
+	set[id] = true
+	st.mu.Unlock()
+
+	if st.path != "" {
+		go st.save()
+	}
+}
+
+// save writes all unlocked achievements to the store's file.
+func (st *achievementStore) save() {
+	st.saveMu.Lock()
+	defer st.saveMu.Unlock()
+
+	st.mu.Lock()
+	saved := make(map[string][]string, len(st.unlocked))
+	for user, set := range st.unlocked {
+		ids := make([]string, 0, len(set))
+		for _, a := range achievements {
+			if set[a.ID] {
+				ids = append(ids, a.ID)
+			}
+		}
+		saved[user] = ids
+	}
+	st.mu.Unlock()
+
+	if err := saveJSONFile(st.path, saved); err != nil {
+		log.Printf("Achievements: %v", err)
+	}
+}
+
+// checkAchievementsLocked unlocks any newly reached achievements for all
+// clients and notifies them. Must be called with s.mu held.
+func (s *Server) checkAchievementsLocked() {
+	for _, handle := range s.clients {
+		progress := handle.progress()
+		for _, a := range achievements {
+			if handle.Achievements[a.ID] || !a.Reached(progress) {
+				continue
+			}
+			handle.Achievements[a.ID] = true
+			s.achievements.unlock(handle.Username, a.ID)
+			select {
+			case handle.EventsCh <- ClientEvent{Type: EventAchievement, Achievement: a.Name}:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConnectChurn registers and unregisters clients thousands of times
+// against a running server, then checks that nothing is left behind: no
+// client entries, no goroutines and no retained memory.
+func TestConnectChurn(t *testing.T) {
+	const (
+		workers = 50
+		cycles  = 100 // per worker
+	)
+
+	s, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	runtime.GC()
+	baseGoroutines := runtime.NumGoroutine()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var last *ClientHandle
+			for i := 0; i < cycles; i++ {
+				last = s.RegisterClient("churn", Preferences{})
+				s.UnregisterClient(last.ID)
+			}
+			// The events channel is closed once the unregistration is applied.
+			timeout := time.After(5 * time.Second)
+			for {
+				select {
+				case _, ok := <-last.EventsCh:
+					if !ok {
+						return
+					}
+				case <-timeout:
+					t.Errorf("client %d: events channel not closed", last.ID)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.mu.RLock()
+	remaining := len(s.clients)
+	s.mu.RUnlock()
+	if remaining != 0 {
+		t.Errorf("%d clients left after churn, want 0", remaining)
+	}
+	if got, want := s.Stats().Churn, int64(2*workers*cycles); got != want {
+		t.Errorf("Churn = %d, want %d", got, want)
+	}
+
+	cancel()
+	<-done
+
+	// Let exited goroutines finish unwinding before counting.
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > baseGoroutines && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > baseGoroutines {
+		t.Errorf("%d goroutines after churn, want at most %d", n, baseGoroutines)
+	}
+
+	runtime.GC()
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	const maxGrowth = 1 << 20
+	if growth := int64(after.HeapAlloc) - int64(before.HeapAlloc); growth > maxGrowth {
+		t.Errorf("heap grew by %d bytes over %d connections, want at most %d", growth, workers*cycles, maxGrowth)
+	}
+}
@@ -8,11 +8,12 @@ import (
 	"github.com/tomz197/asteroids/internal/physics"
 )
 
-// collectCollidables extracts projectiles and asteroids from the object list.
-// Uses pre-allocated slices to avoid allocations.
-func collectCollidables(objects []object.Object, projectiles *[]*object.Projectile, asteroids *[]*object.Asteroid) {
+// collectCollidables extracts projectiles, asteroids and power-ups from the
+// object list. Uses pre-allocated slices to avoid allocations.
+func collectCollidables(objects []object.Object, projectiles *[]*object.Projectile, asteroids *[]*object.Asteroid, powerUps *[]*object.PowerUp) {
 	*projectiles = (*projectiles)[:0]
 	*asteroids = (*asteroids)[:0]
+	*powerUps = (*powerUps)[:0]
 
 	for _, obj := range objects {
 		switch o := obj.(type) {
@@ -20,6 +21,8 @@ func collectCollidables(objects []object.Object, projectiles *[]*object.Projecti
 			*projectiles = append(*projectiles, o)
 		case *object.Asteroid:
 			*asteroids = append(*asteroids, o)
+		case *object.PowerUp:
+			*powerUps = append(*powerUps, o)
 		}
 	}
 }
@@ -45,6 +48,8 @@ func populateGrids(
 // asteroidScore returns the score for destroying an asteroid of the given size.
 func asteroidScore(size object.AsteroidSize) int {
 	switch size {
+	case object.AsteroidHuge:
+		return config.ScoreHugeAsteroid
 	case object.AsteroidLarge:
 		return config.ScoreLargeAsteroid
 	case object.AsteroidMedium:
@@ -152,3 +157,34 @@ func bounceAsteroids(a1, a2 *object.Asteroid, dist float64) {
 		a2.Y += ny * sep2
 	}
 }
+
+// awardScore adds points to a client's score, keeps its best score current
+// and tells the client about it.
+func awardScore(handle *ClientHandle, add int) {
+	if add <= 0 {
+		return
+	}
+	handle.Score += add
+	if handle.Score > handle.BestScore {
+		handle.BestScore = handle.Score
+	}
+	select {
+	case handle.EventsCh <- ClientEvent{Type: EventScoreAdd, ScoreAdd: add}:
+	default:
+	}
+}
+
+// awardAssists gives s.assistFraction of the asteroid's score to every
+// other player who hit it (or an asteroid it split from) within
+// config.AssistWindow. Must be called with s.mu held.
+func (s *Server) awardAssists(a *object.Asteroid, killerID int) {
+	assist := int(math.Round(float64(asteroidScore(a.Size)) * s.assistFraction))
+	for _, h := range a.Hitters {
+		if h.Remaining <= 0 || h.OwnerID == killerID {
+			continue
+		}
+		if handle, ok := s.clients[h.OwnerID]; ok {
+			awardScore(handle, assist)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// TestInvincibleShipSurvivesCollision parks an asteroid on a freshly spawned
+// ship: the ship must survive it for as long as it is invincible, and die
+// on the first tick after.
+func TestInvincibleShipSurvivesCollision(t *testing.T) {
+	s, handles := newTestServer(t, "pilot")
+	h := handles[0]
+	spawnAt(t, s, h, 200, 200, 0)
+	addStillAsteroid(s, 200, 200, object.AsteroidLarge)
+
+	if h.InvincibleTime != config.InvincibilityTime.Seconds() {
+		t.Fatalf("InvincibleTime = %v after spawning, want %v", h.InvincibleTime, config.InvincibilityTime.Seconds())
+	}
+	// The tick that uses up the last of the invincibility is the first
+	// without it
+	ticks := 0
+	for {
+		tick(s)
+		ticks++
+		if h.InvincibleTime <= 0 {
+			break
+		}
+		if h.Player == nil {
+			t.Fatalf("ship destroyed after %d ticks with %.3fs of invincibility left", ticks, h.InvincibleTime)
+		}
+		for _, e := range drainEvents(h) {
+			if e.Type == EventPlayerDied {
+				t.Fatalf("EventPlayerDied after %d ticks while invincible", ticks)
+			}
+		}
+	}
+	if want := int(config.InvincibilityTime / testTick); ticks < want {
+		t.Errorf("invincibility ran out after %d ticks, want at least %d", ticks, want)
+	}
+
+	if h.Player != nil {
+		t.Fatal("ship survived the asteroid after invincibility ran out")
+	}
+	died := false
+	for _, e := range drainEvents(h) {
+		died = died || e.Type == EventPlayerDied
+	}
+	if !died {
+		t.Error("no EventPlayerDied after the ship was destroyed")
+	}
+	if h.Deaths != 1 {
+		t.Errorf("Deaths = %d, want 1", h.Deaths)
+	}
+}
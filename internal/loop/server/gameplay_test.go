@@ -0,0 +1,226 @@
+package server
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// testTick is the simulated time per server tick in these tests.
+const testTick = time.Second / 60
+
+// newTestServer returns a server with an empty world (no spawners: Run is
+// never called) and a client for each name, registered but not spawned.
+func newTestServer(t *testing.T, names ...string) (*Server, []*ClientHandle) {
+	t.Helper()
+	s, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	handles := make([]*ClientHandle, len(names))
+	for i, name := range names {
+		handles[i] = s.RegisterClient(name, Preferences{})
+	}
+	s.processRegistrations()
+	return s, handles
+}
+
+// spawnAt spawns the client's ship, then moves it to (x, y) facing angle at
+// rest, so tests don't depend on the random spawn point.
+func spawnAt(t *testing.T, s *Server, h *ClientHandle, x, y, angle float64) *object.User {
+	t.Helper()
+	if s.SpawnPlayer(h.ID) == nil {
+		t.Fatalf("SpawnPlayer(%d) spawned nothing", h.ID)
+	}
+	p := h.Player
+	p.X, p.Y, p.Angle = x, y, angle
+	return p
+}
+
+// addStillAsteroid adds an asteroid of the given size at (x, y) that neither
+// moves nor spins.
+func addStillAsteroid(s *Server, x, y float64, size object.AsteroidSize) *object.Asteroid {
+	a := object.NewAsteroid(x, y, size, 0)
+	a.VX, a.VY, a.RotationSpeed = 0, 0, 0
+	s.world.AddObject(a)
+	return a
+}
+
+// tick runs one server tick of testTick without the pacing of Run.
+func tick(s *Server) {
+	s.world.Delta = testTick
+	s.collectInputs()
+	s.updateWorld()
+}
+
+// drainEvents returns the events waiting for the client.
+func drainEvents(h *ClientHandle) []ClientEvent {
+	var events []ClientEvent
+	for {
+		select {
+		case e := <-h.EventsCh:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+// countAsteroids counts the live asteroids of each size in the world.
+func countAsteroids(s *Server) map[object.AsteroidSize]int {
+	n := make(map[object.AsteroidSize]int)
+	for _, obj := range s.world.Objects {
+		if a, ok := obj.(*object.Asteroid); ok && !a.IsDestroyed() {
+			n[a.Size]++
+		}
+	}
+	return n
+}
+
+// TestShotDestroysAsteroid fires one shot at a large asteroid and follows it
+// through the collision, the owner's score and the split.
+func TestShotDestroysAsteroid(t *testing.T) {
+	s, handles := newTestServer(t, "shooter", "bystander")
+	shooter, bystander := handles[0], handles[1]
+	spawnAt(t, s, shooter, 100, 100, 0) // Facing right
+	spawnAt(t, s, bystander, 100, 300, 0)
+	target := addStillAsteroid(s, 130, 100, object.AsteroidLarge)
+
+	s.SendInput(shooter.ID, object.Input{Fire: true, FirePress: true})
+	tick(s)
+	s.SendInput(shooter.ID, object.Input{})
+
+	for i := 0; i < 60 && !target.IsDestroyed(); i++ {
+		tick(s)
+	}
+	if !target.IsDestroyed() {
+		t.Fatal("asteroid not destroyed within a second of the shot")
+	}
+	tick(s) // The destroyed asteroid splits on its next update
+
+	if shooter.Score != config.ScoreLargeAsteroid {
+		t.Errorf("shooter score = %d, want %d", shooter.Score, config.ScoreLargeAsteroid)
+	}
+	if shooter.Stats.ShotsFired != 1 || shooter.Stats.ShotsHit != 1 {
+		t.Errorf("shooter fired %d and hit %d, want 1 and 1", shooter.Stats.ShotsFired, shooter.Stats.ShotsHit)
+	}
+	var scored []int
+	for _, e := range drainEvents(shooter) {
+		if e.Type == EventScoreAdd {
+			scored = append(scored, e.ScoreAdd)
+		}
+	}
+	if len(scored) != 1 || scored[0] != config.ScoreLargeAsteroid {
+		t.Errorf("shooter score events = %v, want [%d]", scored, config.ScoreLargeAsteroid)
+	}
+	if bystander.Score != 0 || len(drainEvents(bystander)) != 0 {
+		t.Errorf("bystander score = %d with events, want 0 and none", bystander.Score)
+	}
+
+	counts := countAsteroids(s)
+	if counts[object.AsteroidLarge] != 0 || counts[object.AsteroidMedium] != 2 {
+		t.Errorf("asteroids after the split = %v, want 2 medium and no large", counts)
+	}
+	if want := 2 * s.world.AsteroidSplit.Weight(object.AsteroidMedium); s.world.AsteroidCount != want {
+		t.Errorf("AsteroidCount = %d, want %d", s.world.AsteroidCount, want)
+	}
+}
+
+// TestAssistsShareScore has one player break a large asteroid and another
+// finish off a fragment: with "assists" set, the first player gets that
+// share of the fragment's score as well as their own kill.
+func TestAssistsShareScore(t *testing.T) {
+	writeWorldFile(t, `{"assists": 0.5}`)
+	s, handles := newTestServer(t, "breaker", "finisher")
+	breaker, finisher := handles[0], handles[1]
+	spawnAt(t, s, breaker, 100, 100, 0)
+	spawnAt(t, s, finisher, 100, 300, 0)
+	target := addStillAsteroid(s, 130, 100, object.AsteroidLarge)
+
+	// shoot fires one shot from the client's ship and waits for it to land
+	shoot := func(h *ClientHandle, a *object.Asteroid) {
+		t.Helper()
+		s.SendInput(h.ID, object.Input{Fire: true, FirePress: true})
+		tick(s)
+		s.SendInput(h.ID, object.Input{})
+		for i := 0; i < 60 && !a.IsDestroyed(); i++ {
+			tick(s)
+		}
+		if !a.IsDestroyed() {
+			t.Fatal("asteroid not destroyed within a second of the shot")
+		}
+		tick(s) // The destroyed asteroid splits on its next update
+	}
+	shoot(breaker, target)
+
+	// Stop the fragments and line one up in front of the finisher
+	var fragment *object.Asteroid
+	for _, obj := range s.world.Objects {
+		if a, ok := obj.(*object.Asteroid); ok && !a.IsDestroyed() {
+			a.VX, a.VY, a.RotationSpeed = 0, 0, 0
+			a.SpawnProtection = 0
+			if fragment == nil {
+				fragment = a
+				a.X, a.Y = 130, 300
+			} else {
+				a.X, a.Y = 300, 200
+			}
+		}
+	}
+	if fragment == nil {
+		t.Fatal("large asteroid left no fragments")
+	}
+	shoot(finisher, fragment)
+
+	assist := int(math.Round(config.ScoreMediumAsteroid * 0.5))
+	if want := config.ScoreLargeAsteroid + assist; breaker.Score != want {
+		t.Errorf("breaker score = %d, want %d (kill plus assist)", breaker.Score, want)
+	}
+	if finisher.Score != config.ScoreMediumAsteroid {
+		t.Errorf("finisher score = %d, want %d", finisher.Score, config.ScoreMediumAsteroid)
+	}
+}
+
+// TestPierceShotPassesThroughSmallAsteroids fires one pierce shot down a
+// row of small asteroids: it destroys PiercePowerUp of them and flies on,
+// and is spent on the next. Each asteroid scores, but the shot is one hit.
+func TestPierceShotPassesThroughSmallAsteroids(t *testing.T) {
+	s, handles := newTestServer(t, "shooter")
+	h := handles[0]
+	ship := spawnAt(t, s, h, 100, 100, 0)
+	ship.ApplyPowerUp(object.PowerUpPierce, 10)
+
+	var row []*object.Asteroid
+	for i := 0; i < object.PiercePowerUp+2; i++ {
+		row = append(row, addStillAsteroid(s, 120+float64(i)*10, 100, object.AsteroidSmall))
+	}
+
+	s.SendInput(h.ID, object.Input{Fire: true, FirePress: true})
+	tick(s)
+	s.SendInput(h.ID, object.Input{})
+	for i := 0; i < 60; i++ {
+		tick(s)
+	}
+
+	for i, a := range row[:object.PiercePowerUp+1] {
+		if !a.IsDestroyed() {
+			t.Errorf("asteroid %d survived, want destroyed by the pierce shot", i)
+		}
+	}
+	if last := row[len(row)-1]; last.IsDestroyed() {
+		t.Error("last asteroid destroyed, want the shot spent before it")
+	}
+	// One shot is one hit, however many asteroids it destroys
+	if h.Stats.ShotsFired != 1 || h.Stats.ShotsHit != 1 {
+		t.Errorf("fired %d and hit %d, want 1 and 1", h.Stats.ShotsFired, h.Stats.ShotsHit)
+	}
+	if want := object.PiercePowerUp + 1; h.AsteroidsDestroyed != want {
+		t.Errorf("AsteroidsDestroyed = %d, want %d", h.AsteroidsDestroyed, want)
+	}
+	if want := (object.PiercePowerUp + 1) * config.ScoreSmallAsteroid; h.Score != want {
+		t.Errorf("score = %d, want %d", h.Score, want)
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	envconfig "github.com/tomz197/asteroids/internal/config"
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// loadInterestLimit returns the per-client object cap from the
+// MAX_VISIBLE_OBJECTS env var (default 0: no cap beyond the interest region).
+func loadInterestLimit() (int, error) {
+	v := envconfig.GetEnv("MAX_VISIBLE_OBJECTS", "")
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid MAX_VISIBLE_OBJECTS %q: must be a non-negative integer", v)
+	}
+	return n, nil
+}
+
+// objectPosition returns where a snapshot object is, or false for objects
+// without a place in the world (labels, the spawner), which every client
+// keeps.
+func objectPosition(obj object.Object) (x, y float64, ok bool) {
+	switch o := obj.(type) {
+	case *object.Asteroid:
+		return o.X, o.Y, true
+	case *object.Projectile:
+		return o.X, o.Y, true
+	case *object.Particle:
+		return o.X, o.Y, true
+	case *object.User:
+		return o.X, o.Y, true
+	case *object.PowerUp:
+		return o.X, o.Y, true
+	}
+	return 0, 0, false
+}
+
+// SetCamera records where the client's camera is, so its snapshot view is
+// built around it (see GetSnapshotFor). Called by the client every frame;
+// safe to use concurrently with the server loop.
+func (h *ClientHandle) SetCamera(x, y float64) {
+	h.cameraX.Store(math.Float64bits(x))
+	h.cameraY.Store(math.Float64bits(y))
+	h.hasCamera.Store(true)
+}
+
+// viewCenter is a point a client's view is built around.
+type viewCenter struct {
+	x, y float64
+}
+
+// interestCenters returns the points a client's view is built around. With
+// a ship, that is always the ship where it is now, plus the reported camera
+// if any: the camera catches up with the ship a frame or more late, and
+// after a respawn or hyperspace jump it is still far behind. Without a ship
+// it is the camera, else where its last ship died, else the world center.
+// The returned slice reuses s.viewCenters.
+func (s *Server) interestCenters(snap *WorldSnapshot, handle *ClientHandle) []viewCenter {
+	centers := s.viewCenters[:0]
+	if ship := snap.Ship(handle.ID); ship != nil {
+		centers = append(centers, viewCenter{ship.X, ship.Y})
+	}
+	if handle.hasCamera.Load() {
+		centers = append(centers, viewCenter{
+			math.Float64frombits(handle.cameraX.Load()),
+			math.Float64frombits(handle.cameraY.Load()),
+		})
+	}
+	if len(centers) == 0 {
+		if handle.hasLastDeath {
+			centers = append(centers, viewCenter{handle.LastDeathX, handle.LastDeathY})
+		} else {
+			centers = append(centers, viewCenter{float64(snap.World.Width) / 2, float64(snap.World.Height) / 2})
+		}
+	}
+	s.viewCenters = centers
+	return centers
+}
+
+// updateViewsLocked gives every client a copy of snap holding only what it
+// can see, in the original draw order:
+//
+//   - with interest management (s.interest), the objects within the view
+//     plus config.InterestMargin around its ship and around its camera,
+//     looked up in s.interestGrid, and every ship;
+//   - with MAX_VISIBLE_OBJECTS (s.interestLimit), at most that many of
+//     those, nearest to the ship or camera first.
+//
+// Objects without a position are always kept, and UserObjects stays
+// complete for the minimap and player names. When neither filter can drop
+// anything, clients share snap. Called from the server loop only, with s.mu
+// held.
+func (s *Server) updateViewsLocked(snap *WorldSnapshot) {
+	limit := s.interestLimit
+	if !s.interest && (limit <= 0 || len(snap.Objects) <= limit) {
+		for _, handle := range s.clients {
+			handle.view.Store(nil)
+		}
+		return
+	}
+
+	if s.interest {
+		s.interestGrid.Clear()
+		for i, obj := range snap.Objects {
+			if _, isShip := obj.(*object.User); isShip {
+				continue
+			}
+			if x, y, ok := objectPosition(obj); ok {
+				s.interestGrid.Insert(x, y, i)
+			}
+		}
+	}
+
+	w, h := float64(snap.World.Width), float64(snap.World.Height)
+	halfW := float64(config.ViewWidth)/2 + config.InterestMargin
+	halfH := float64(config.ViewHeight)/2 + config.InterestMargin
+	// distance returns the squared distance from (x, y) to the nearest center
+	distance := func(centers []viewCenter, x, y float64) float64 {
+		best := math.Inf(1)
+		for _, c := range centers {
+			dx, dy := x-c.x, y-c.y
+			if !snap.Bounded {
+				dx, dy = wrapDelta(dx, w), wrapDelta(dy, h)
+			}
+			best = min(best, dx*dx+dy*dy)
+		}
+		return best
+	}
+	for _, handle := range s.clients {
+		centers := s.interestCenters(snap, handle)
+
+		// Distance to each candidate; -1 keeps an object unconditionally,
+		// +Inf drops it
+		s.interestDist = s.interestDist[:0]
+		for _, obj := range snap.Objects {
+			d := -1.0
+			if x, y, ok := objectPosition(obj); ok {
+				if _, isShip := obj.(*object.User); !isShip && s.interest {
+					d = math.Inf(1) // Until the region query below finds it
+				} else {
+					d = distance(centers, x, y)
+				}
+			}
+			s.interestDist = append(s.interestDist, d)
+		}
+		if s.interest {
+			for _, c := range centers {
+				s.interestGrid.QueryRect(c.x-halfW, c.y-halfH, c.x+halfW, c.y+halfH, func(i int) bool {
+					if math.IsInf(s.interestDist[i], 1) {
+						x, y, _ := objectPosition(snap.Objects[i])
+						s.interestDist[i] = distance(centers, x, y)
+					}
+					return false
+				})
+			}
+		}
+
+		cutoff := math.MaxFloat64
+		if limit > 0 && len(snap.Objects) > limit {
+			s.interestSorted = append(s.interestSorted[:0], s.interestDist...)
+			slices.Sort(s.interestSorted)
+			cutoff = min(s.interestSorted[limit-1], cutoff)
+		}
+		objects := make([]object.Object, 0, min(len(snap.Objects), max(limit, 64)))
+		for i, obj := range snap.Objects {
+			if s.interestDist[i] <= cutoff && (limit <= 0 || len(objects) < limit) {
+				objects = append(objects, obj)
+			}
+		}
+		view := *snap
+		view.Objects = objects
+		handle.view.Store(&view)
+	}
+}
+
+// GetSnapshotFor returns the snapshot as seen by a client: limited to what
+// is around its camera (see updateViewsLocked), or the shared snapshot when
+// nothing is filtered.
+func (s *Server) GetSnapshotFor(clientID int) *WorldSnapshot {
+	s.mu.RLock()
+	handle, ok := s.clients[clientID]
+	s.mu.RUnlock()
+	if ok {
+		if view := handle.view.Load(); view != nil {
+			return view
+		}
+	}
+	return s.snapshot.Load()
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// inView reports whether obj is in the client's view of the snapshot.
+func inView(view *WorldSnapshot, obj object.Object) bool {
+	for _, o := range view.Objects {
+		x1, y1, _ := objectPosition(o)
+		x2, y2, _ := objectPosition(obj)
+		if x1 == x2 && y1 == y2 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestInterestManagementOffByDefault(t *testing.T) {
+	t.Setenv("INTEREST_MANAGEMENT", "")
+	s, handles := newTestServer(t, "pilot")
+	spawnAt(t, s, handles[0], 50, 50, 0)
+	addStillAsteroid(s, 300, 300, object.AsteroidLarge)
+	s.createSnapshot()
+
+	if view := s.GetSnapshotFor(handles[0].ID); view != s.GetSnapshot() {
+		t.Error("client got its own view with interest management off, want the shared snapshot")
+	}
+}
+
+// TestInterestKeepsObjectsAroundShip covers the frame after a respawn or
+// hyperspace jump: the reported camera is still at the old spot, far from
+// the ship, and both must be covered.
+func TestInterestKeepsObjectsAroundShip(t *testing.T) {
+	t.Setenv("INTEREST_MANAGEMENT", "true")
+	s, handles := newTestServer(t, "pilot", "other")
+	h := handles[0]
+	spawnAt(t, s, h, 300, 300, 0)
+	other := spawnAt(t, s, handles[1], 150, 20, 0)
+	h.SetCamera(50, 50)
+
+	nearShip := addStillAsteroid(s, 340, 320, object.AsteroidLarge)
+	nearCamera := addStillAsteroid(s, 70, 40, object.AsteroidLarge)
+	far := addStillAsteroid(s, 180, 180, object.AsteroidLarge)
+	s.createSnapshot()
+
+	view := s.GetSnapshotFor(h.ID)
+	if !inView(view, nearShip) {
+		t.Error("asteroid next to the ship left out while the camera is elsewhere")
+	}
+	if !inView(view, nearCamera) {
+		t.Error("asteroid next to the camera left out")
+	}
+	if inView(view, far) {
+		t.Error("asteroid far from both ship and camera included")
+	}
+	if !inView(view, other) {
+		t.Error("another player's ship left out; every ship is always sent")
+	}
+}
+
+// TestInterestNeverDropsNearbyObjects places random objects around random
+// ships and cameras, across the world edges too, and checks that nothing
+// within the view of either is ever left out.
+func TestInterestNeverDropsNearbyObjects(t *testing.T) {
+	t.Setenv("INTEREST_MANAGEMENT", "true")
+	rng := rand.New(rand.NewSource(1))
+	halfW, halfH := float64(config.ViewWidth)/2, float64(config.ViewHeight)/2
+
+	for trial := 0; trial < 50; trial++ {
+		s, handles := newTestServer(t, "pilot")
+		h := handles[0]
+		w, ht := float64(s.world.World.Width), float64(s.world.World.Height)
+		ship := spawnAt(t, s, h, rng.Float64()*w, rng.Float64()*ht, 0)
+		camX, camY := ship.X, ship.Y
+		if trial%2 == 0 {
+			// Camera left behind by a jump
+			camX, camY = rng.Float64()*w, rng.Float64()*ht
+		}
+		h.SetCamera(camX, camY)
+
+		var asteroids []*object.Asteroid
+		for i := 0; i < 100; i++ {
+			asteroids = append(asteroids, addStillAsteroid(s, rng.Float64()*w, rng.Float64()*ht, object.AsteroidSmall))
+		}
+		s.createSnapshot()
+		view := s.GetSnapshotFor(h.ID)
+
+		visible := func(a *object.Asteroid, cx, cy float64) bool {
+			dx, dy := wrapDelta(a.X-cx, w), wrapDelta(a.Y-cy, ht)
+			return math.Abs(dx) <= halfW && math.Abs(dy) <= halfH
+		}
+		for _, a := range asteroids {
+			if (visible(a, ship.X, ship.Y) || visible(a, camX, camY)) && !inView(view, a) {
+				t.Fatalf("trial %d: asteroid at (%.1f, %.1f) in view of ship (%.1f, %.1f) or camera (%.1f, %.1f) left out",
+					trial, a.X, a.Y, ship.X, ship.Y, camX, camY)
+			}
+		}
+	}
+}
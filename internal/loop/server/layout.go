@@ -0,0 +1,179 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/object"
+	"github.com/tomz197/asteroids/internal/physics"
+)
+
+// World layout limits, checked when a WORLD_FILE is loaded.
+const (
+	minWorldSize      = 100   // Smallest world edge (must fit the viewport comfortably)
+	maxWorldSize      = 4000  // Largest world edge (bounds grid and snapshot sizes)
+	maxAsteroidTarget = 10000 // Largest weighted asteroid target
+	maxLabelLength    = 40    // Longest label text in runes
+	maxGravity        = 20.0  // Strongest pull toward the center; ship thrust (40) must still escape it
+)
+
+// WorldLayout is the world description optionally loaded from WORLD_FILE.
+// Zero or omitted fields fall back to the built-in defaults.
+type WorldLayout struct {
+	Width            int         `json:"width"`              // World width (default config.WorldWidth)
+	Height           int         `json:"height"`             // World height (default config.WorldHeight)
+	Asteroids        *int        `json:"asteroids"`          // Weighted asteroid target (default config.InitialAsteroidTarget)
+	InitialAsteroids *int        `json:"initial_asteroids"`  // Weighted asteroids seeded at startup, then ramped to the target (default: the target)
+	Waves            bool        `json:"waves"`              // Asteroids come in growing waves instead of a steady population; the targets above are then unused
+	Bounded          bool        `json:"bounded"`            // World edges are walls that ships, asteroids and shots bounce off, instead of wrapping around
+	Drag             string      `json:"drag"`               // How velocity decays under drag: "exponential" (default; the same at any tick rate) or "linear"
+	Gravity          float64     `json:"gravity"`            // Pull of ships and asteroids toward the world center in units/s² ("planet" mode); 0 keeps free drift
+	Assists          float64     `json:"assists"`            // Share (0-1) of an asteroid's score given to other recent hitters when it is destroyed; 0 keeps killer-takes-all
+	HugeAsteroids    float64     `json:"huge_asteroids"`     // Chance (0-1) a spawned asteroid is huge and splits into larges; 0 keeps the classic three tiers
+	RespawnNearDeath bool        `json:"respawn_near_death"` // Ships respawn near where they were destroyed instead of anywhere in the world
+	Labels           []LabelSpec `json:"labels"`
+}
+
+// LabelSpec places a static text label in the world.
+type LabelSpec struct {
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Text string  `json:"text"`
+}
+
+// DefaultWorldLayout returns the layout used when no WORLD_FILE is set.
+func DefaultWorldLayout() WorldLayout {
+	target := config.InitialAsteroidTarget
+	return WorldLayout{
+		Width:     config.WorldWidth,
+		Height:    config.WorldHeight,
+		Asteroids: &target,
+	}
+}
+
+// LoadWorldLayout reads and validates the world description at path.
+// An empty path yields the default layout. Unknown fields, a missing file
+// and out-of-range values are errors, so a typo in an operator's file is
+// reported at startup instead of silently ignored.
+func LoadWorldLayout(path string) (WorldLayout, error) {
+	layout := DefaultWorldLayout()
+	if path == "" {
+		return layout, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return layout, fmt.Errorf("read world file: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&layout); err != nil {
+		return layout, fmt.Errorf("decode world file %s: %w", path, err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return layout, fmt.Errorf("decode world file %s: trailing data after layout", path)
+	}
+
+	// Omitted sizes keep their defaults
+	if layout.Width == 0 {
+		layout.Width = config.WorldWidth
+	}
+	if layout.Height == 0 {
+		layout.Height = config.WorldHeight
+	}
+	if err := layout.validate(); err != nil {
+		return layout, fmt.Errorf("world file %s: %w", path, err)
+	}
+	return layout, nil
+}
+
+// validate checks that all values are within supported bounds.
+func (l WorldLayout) validate() error {
+	if l.Width < minWorldSize || l.Width > maxWorldSize {
+		return fmt.Errorf("width %d out of range [%d, %d]", l.Width, minWorldSize, maxWorldSize)
+	}
+	if l.Height < minWorldSize || l.Height > maxWorldSize {
+		return fmt.Errorf("height %d out of range [%d, %d]", l.Height, minWorldSize, maxWorldSize)
+	}
+	if l.Asteroids != nil && (*l.Asteroids < 0 || *l.Asteroids > maxAsteroidTarget) {
+		return fmt.Errorf("asteroids %d out of range [0, %d]", *l.Asteroids, maxAsteroidTarget)
+	}
+	if l.InitialAsteroids != nil && (*l.InitialAsteroids < 0 || *l.InitialAsteroids > maxAsteroidTarget) {
+		return fmt.Errorf("initial_asteroids %d out of range [0, %d]", *l.InitialAsteroids, maxAsteroidTarget)
+	}
+	if l.Gravity < 0 || l.Gravity > maxGravity {
+		return fmt.Errorf("gravity %g out of range [0, %g]", l.Gravity, maxGravity)
+	}
+	if l.Assists < 0 || l.Assists > 1 {
+		return fmt.Errorf("assists %g out of range [0, 1]", l.Assists)
+	}
+	if l.HugeAsteroids < 0 || l.HugeAsteroids > 1 {
+		return fmt.Errorf("huge_asteroids %g out of range [0, 1]", l.HugeAsteroids)
+	}
+	if _, ok := physics.DragModelByName(l.Drag); !ok {
+		return fmt.Errorf("drag %q: must be \"exponential\" or \"linear\"", l.Drag)
+	}
+	for i, spec := range l.Labels {
+		if spec.X < 0 || spec.X >= float64(l.Width) || spec.Y < 0 || spec.Y >= float64(l.Height) {
+			return fmt.Errorf("label %d: position (%g, %g) outside the %dx%d world", i, spec.X, spec.Y, l.Width, l.Height)
+		}
+		if err := validateLabelText(spec.Text); err != nil {
+			return fmt.Errorf("label %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateLabelText rejects empty, overlong or non-printable label text.
+// Labels are written straight to player terminals, so control characters
+// (including escape sequences) must never get through.
+func validateLabelText(text string) error {
+	if text == "" {
+		return errors.New("empty text")
+	}
+	if n := utf8.RuneCountInString(text); n > maxLabelLength {
+		return fmt.Errorf("text is %d characters, max %d", n, maxLabelLength)
+	}
+	for _, r := range text {
+		if r == utf8.RuneError || !unicode.IsPrint(r) {
+			return fmt.Errorf("text %q contains a non-printable character", text)
+		}
+	}
+	return nil
+}
+
+// asteroidTarget returns the weighted asteroid target for the spawner.
+func (l WorldLayout) asteroidTarget() int {
+	if l.Asteroids == nil {
+		return config.InitialAsteroidTarget
+	}
+	return *l.Asteroids
+}
+
+// initialAsteroids returns the weighted population seeded at startup.
+func (l WorldLayout) initialAsteroids() int {
+	if l.InitialAsteroids == nil {
+		return l.asteroidTarget()
+	}
+	return *l.InitialAsteroids
+}
+
+// dragModel returns how velocity decays under drag.
+func (l WorldLayout) dragModel() physics.DragModel {
+	model, _ := physics.DragModelByName(l.Drag)
+	return model
+}
+
+// apply adds the layout's static objects to the world.
+func (l WorldLayout) apply(w *WorldState) {
+	for _, spec := range l.Labels {
+		w.AddObject(object.NewLabel(spec.X, spec.Y, spec.Text))
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tomz197/asteroids/internal/object"
+	"github.com/tomz197/asteroids/internal/physics"
+)
+
+// writeWorldFile writes a world layout file and points WORLD_FILE at it.
+func writeWorldFile(t *testing.T, data string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "world.json")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("WORLD_FILE", path)
+}
+
+func TestLoadWorldLayoutOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+		check   func(t *testing.T, s *Server)
+	}{
+		{"defaults", `{}`, false, func(t *testing.T, s *Server) {
+			if s.drag != physics.DragExponential {
+				t.Errorf("drag = %v, want exponential", s.drag)
+			}
+			if s.gravity != 0 {
+				t.Errorf("gravity = %v, want 0", s.gravity)
+			}
+			if s.assistFraction != 0 {
+				t.Errorf("assistFraction = %v, want 0", s.assistFraction)
+			}
+			if s.hugeChance != 0 {
+				t.Errorf("hugeChance = %v, want 0", s.hugeChance)
+			}
+		}},
+		{"linear drag", `{"drag": "linear"}`, false, func(t *testing.T, s *Server) {
+			if s.drag != physics.DragLinear {
+				t.Errorf("drag = %v, want linear", s.drag)
+			}
+		}},
+		{"unknown drag", `{"drag": "quadratic"}`, true, nil},
+		{"gravity", `{"gravity": 5}`, false, func(t *testing.T, s *Server) {
+			if s.gravity != 5 {
+				t.Errorf("gravity = %v, want 5", s.gravity)
+			}
+			// An asteroid left of center starts falling toward it
+			a := addStillAsteroid(s, 100, 200, object.AsteroidLarge)
+			tick(s)
+			if a.VX <= 0 || a.VY != 0 {
+				t.Errorf("asteroid velocity (%v, %v) after a tick of gravity, want toward the center", a.VX, a.VY)
+			}
+		}},
+		{"negative gravity", `{"gravity": -1}`, true, nil},
+		{"gravity stronger than thrust", `{"gravity": 50}`, true, nil},
+		{"assists", `{"assists": 0.25}`, false, func(t *testing.T, s *Server) {
+			if s.assistFraction != 0.25 {
+				t.Errorf("assistFraction = %v, want 0.25", s.assistFraction)
+			}
+		}},
+		{"assists over the whole score", `{"assists": 1.5}`, true, nil},
+		{"huge asteroids", `{"huge_asteroids": 1, "asteroids": 40}`, false, func(t *testing.T, s *Server) {
+			// The spawner seeds the world with huge asteroids only
+			s.addSpawners()
+			tick(s)
+			counts := countAsteroids(s)
+			if want := 40 / s.world.AsteroidSplit.Weight(object.AsteroidHuge); counts[object.AsteroidHuge] != want || len(counts) != 1 {
+				t.Errorf("seeded asteroids = %v, want %d huge", counts, want)
+			}
+		}},
+		{"huge asteroid chance over 1", `{"huge_asteroids": 2}`, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeWorldFile(t, tt.data)
+			s, err := NewServer()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewServer accepted an invalid world file")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewServer: %v", err)
+			}
+			tt.check(t, s)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import "github.com/tomz197/asteroids/internal/object"
+
+// overviewGrid is the number of density cells along each world axis in a
+// WorldOverview.
+const overviewGrid = 16
+
+// WorldOverview is a coarse, read-only picture of the world for dashboards:
+// where the ships are and how asteroids are spread, without the objects
+// themselves.
+type WorldOverview struct {
+	Width     int              `json:"width"`     // World width
+	Height    int              `json:"height"`    // World height
+	Players   []PlayerPosition `json:"players"`   // Ships currently in the world
+	Asteroids int              `json:"asteroids"` // Asteroids in the world
+	// Density counts asteroids per cell of an overviewGrid x overviewGrid
+	// grid over the world, row by row from the top-left.
+	Density [overviewGrid][overviewGrid]int `json:"density"`
+}
+
+// PlayerPosition is a ship's place in a WorldOverview.
+type PlayerPosition struct {
+	Name string  `json:"name"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+}
+
+// Overview summarizes the latest snapshot (thread-safe). It reads only the
+// snapshot, so polling it never touches the server lock or the live world.
+func (s *Server) Overview() WorldOverview {
+	snap := s.GetSnapshot()
+	ov := WorldOverview{Width: snap.World.Width, Height: snap.World.Height}
+	if ov.Width <= 0 || ov.Height <= 0 {
+		return ov
+	}
+
+	ov.Players = make([]PlayerPosition, 0, len(snap.UserObjects))
+	for _, u := range snap.UserObjects {
+		ov.Players = append(ov.Players, PlayerPosition{Name: u.Username, X: u.X, Y: u.Y})
+	}
+
+	cellW := float64(ov.Width) / overviewGrid
+	cellH := float64(ov.Height) / overviewGrid
+	for _, obj := range snap.Objects {
+		a, ok := obj.(*object.Asteroid)
+		if !ok {
+			continue
+		}
+		ov.Asteroids++
+		col := min(max(int(a.X/cellW), 0), overviewGrid-1)
+		row := min(max(int(a.Y/cellH), 0), overviewGrid-1)
+		ov.Density[row][col]++
+	}
+	return ov
+}
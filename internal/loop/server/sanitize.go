@@ -0,0 +1,41 @@
+package server
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+)
+
+// SanitizeUsername strips control characters and escape sequences from a
+// username to prevent terminal injection attacks, then caps it to
+// config.MaxUsernameLength runes.
+func SanitizeUsername(raw string) string {
+	return sanitizeText(raw, config.MaxUsernameLength)
+}
+
+// SanitizeChatMessage strips control characters and escape sequences from a
+// chat message, as SanitizeUsername does, capping it to
+// config.MaxChatMessageLength runes.
+func SanitizeChatMessage(raw string) string {
+	return sanitizeText(raw, config.MaxChatMessageLength)
+}
+
+// sanitizeText keeps the first limit graphic runes of raw, without
+// surrounding spaces.
+func sanitizeText(raw string, limit int) string {
+	var b strings.Builder
+	b.Grow(len(raw))
+	count := 0
+	for _, r := range raw {
+		if !unicode.IsGraphic(r) {
+			continue
+		}
+		if count >= limit {
+			break
+		}
+		b.WriteRune(r)
+		count++
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+)
+
+// ScoreRecord is an entry on the all-time high score list.
+type ScoreRecord struct {
+	Username string    `json:"username"`
+	Score    int       `json:"score"`
+	At       time.Time `json:"at"` // When the score was set
+}
+
+// scoreStore keeps each username's personal best score and the all-time
+// high score list, and optionally persists both to a JSON file. Safe for
+// concurrent use.
+type scoreStore struct {
+	mu      sync.Mutex
+	path    string         // JSON file path; "" keeps scores in memory only
+	bests   map[string]int // username -> best score across sessions
+	records []ScoreRecord  // All-time top scores, best first; replaced, never modified
+	saveMu  sync.Mutex     // Serializes file writes
+}
+
+// savedScores is the on-disk layout of a scoreStore.
+type savedScores struct {
+	PersonalBests map[string]int `json:"personal_bests"`
+	Records       []ScoreRecord  `json:"records"` // Absent in files written before the all-time list
+}
+
+// newScoreStore creates a store backed by the file at path, loading any
+// previously saved scores. A missing or corrupt file starts empty. A file
+// without an all-time list seeds it from the personal bests, with unknown
+// times.
+func newScoreStore(path string) *scoreStore {
+	st := &scoreStore{
+		path:  path,
+		bests: make(map[string]int),
+	}
+	if path == "" {
+		return st
+	}
+	var saved savedScores
+	if err := loadJSONFile(path, &saved); err != nil {
+		log.Printf("Scores: %v; starting empty", err)
+		return st
+	}
+	for user, score := range saved.PersonalBests {
+		st.bests[user] = score
+	}
+	records := saved.Records
+	if records == nil {
+		for user, score := range saved.PersonalBests {
+			records = append(records, ScoreRecord{Username: user, Score: score})
+		}
+		// Times are unknown, so order ties by name rather than map order
+		slices.SortFunc(records, func(a, b ScoreRecord) int {
+			return strings.Compare(a.Username, b.Username)
+		})
+	}
+	for _, r := range records {
+		if r.Username != "" && r.Score > 0 {
+			st.records = insertRecord(st.records, r)
+		}
+	}
+	return st
+}
+
+// best returns username's personal best, or 0 if none is recorded.
+func (st *scoreStore) best(username string) int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.bests[username]
+}
+
+// top returns the all-time high score list, best first. The slice is shared
+// and must not be modified.
+func (st *scoreStore) top() []ScoreRecord {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.records
+}
+
+// record raises username's personal best to score if it is higher, updating
+// the all-time list with the score set at now and saving the store in the
+// background. Anonymous players are not recorded.
+func (st *scoreStore) record(username string, score int, now time.Time) {
+	if username == "" || score <= 0 {
+		return
+	}
+	st.mu.Lock()
+	if score <= st.bests[username] {
+		st.mu.Unlock()
+		return
+	}
+	st.bests[username] = score
+	st.records = insertRecord(st.records, ScoreRecord{Username: username, Score: score, At: now})
+	st.mu.Unlock()
+
+	if st.path != "" {
+		go st.save()
+	}
+}
+
+// insertRecord returns a copy of records with r in place of the user's
+// earlier entry, sorted best first and capped at config.AllTimeScoresCount.
+// Each username appears at most once. Ties keep the earlier score ahead.
+func insertRecord(records []ScoreRecord, r ScoreRecord) []ScoreRecord {
+	out := make([]ScoreRecord, 0, len(records)+1)
+	for _, e := range records {
+		if e.Username != r.Username {
+			out = append(out, e)
+		}
+	}
+	out = append(out, r)
+	slices.SortStableFunc(out, func(a, b ScoreRecord) int {
+		if a.Score != b.Score {
+			return b.Score - a.Score
+		}
+		return a.At.Compare(b.At)
+	})
+	if len(out) > config.AllTimeScoresCount {
+		out = out[:config.AllTimeScoresCount]
+	}
+	return out
+}
+
+// save writes all personal bests and the all-time list to the store's file.
+// A no-op for in-memory stores.
+func (st *scoreStore) save() {
+	if st.path == "" {
+		return
+	}
+	st.saveMu.Lock()
+	defer st.saveMu.Unlock()
+
+	st.mu.Lock()
+	saved := savedScores{
+		PersonalBests: make(map[string]int, len(st.bests)),
+		Records:       st.records,
+	}
+	for user, score := range st.bests {
+		saved.PersonalBests[user] = score
+	}
+	st.mu.Unlock()
+
+	if err := saveJSONFile(st.path, saved); err != nil {
+		log.Printf("Scores: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tomz197/asteroids/internal/clock"
+	"github.com/tomz197/asteroids/internal/loop/config"
+)
+
+// writeScoreFile writes data to a scores file in a temporary directory and
+// returns its path.
+func writeScoreFile(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scores.json")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestScoreStoreSeedsRecordsFromBests(t *testing.T) {
+	path := writeScoreFile(t, `{"personal_bests":{"bob":300,"amy":300,"cat":500,"dan":0}}`)
+	st := newScoreStore(path)
+
+	want := []ScoreRecord{{Username: "cat", Score: 500}, {Username: "amy", Score: 300}, {Username: "bob", Score: 300}}
+	got := st.top()
+	if len(got) != len(want) {
+		t.Fatalf("top() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("top()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if st.best("bob") != 300 {
+		t.Errorf("best(bob) = %d, want 300", st.best("bob"))
+	}
+}
+
+func TestScoreStoreKeepsSavedRecords(t *testing.T) {
+	// An empty list was saved deliberately, so the bests must not refill it
+	path := writeScoreFile(t, `{"personal_bests":{"amy":300},"records":[]}`)
+	if got := newScoreStore(path).top(); len(got) != 0 {
+		t.Errorf("top() = %+v, want empty", got)
+	}
+}
+
+func TestScoreStoreCorruptFileStartsEmpty(t *testing.T) {
+	st := newScoreStore(writeScoreFile(t, `{"personal_bests":`))
+	if len(st.top()) != 0 || st.best("amy") != 0 {
+		t.Errorf("corrupt file loaded top() = %+v, best(amy) = %d", st.top(), st.best("amy"))
+	}
+}
+
+func TestScoreStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scores.json")
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	st := &scoreStore{bests: make(map[string]int)} // In memory, so record doesn't save in the background
+	st.record("amy", 200, at)
+	st.record("bob", 400, at.Add(time.Minute))
+	st.path = path
+	st.save()
+
+	loaded := newScoreStore(path)
+	if loaded.best("amy") != 200 || loaded.best("bob") != 400 {
+		t.Errorf("loaded bests amy=%d bob=%d, want 200 and 400", loaded.best("amy"), loaded.best("bob"))
+	}
+	got := loaded.top()
+	if len(got) != 2 || got[0].Username != "bob" || !got[0].At.Equal(at.Add(time.Minute)) ||
+		got[1].Username != "amy" || !got[1].At.Equal(at) {
+		t.Errorf("loaded top() = %+v", got)
+	}
+}
+
+func TestScoreStoreRecord(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	st := newScoreStore("")
+
+	st.record("", 900, at)    // Anonymous
+	st.record("amy", 0, at)   // Nothing scored
+	st.record("amy", 100, at) // First score
+	st.record("amy", 50, at.Add(time.Hour))
+
+	got := st.top()
+	if len(got) != 1 || got[0] != (ScoreRecord{Username: "amy", Score: 100, At: at}) {
+		t.Errorf("top() = %+v, want only amy's 100 at %v", got, at)
+	}
+	if st.best("") != 0 {
+		t.Errorf("anonymous best = %d, want 0", st.best(""))
+	}
+}
+
+func TestInsertRecord(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var records []ScoreRecord
+	for i := range config.AllTimeScoresCount + 2 {
+		name := string(rune('a' + i))
+		records = insertRecord(records, ScoreRecord{Username: name, Score: 100 * (i + 1), At: at})
+	}
+	if len(records) != config.AllTimeScoresCount {
+		t.Fatalf("len = %d, want cap %d", len(records), config.AllTimeScoresCount)
+	}
+	for i := 1; i < len(records); i++ {
+		if records[i].Score > records[i-1].Score {
+			t.Errorf("records not best first: %+v", records)
+		}
+	}
+
+	// A user's new score replaces their entry instead of adding another
+	top := records[0].Username
+	records = insertRecord(records, ScoreRecord{Username: top, Score: 10000, At: at})
+	seen := 0
+	for _, r := range records {
+		if r.Username == top {
+			seen++
+		}
+	}
+	if seen != 1 || records[0].Score != 10000 {
+		t.Errorf("after replacing %s's score: %+v", top, records)
+	}
+
+	// Ties keep the earlier score ahead
+	records = insertRecord(nil, ScoreRecord{Username: "late", Score: 500, At: at.Add(time.Hour)})
+	records = insertRecord(records, ScoreRecord{Username: "early", Score: 500, At: at})
+	if records[0].Username != "early" {
+		t.Errorf("tie order = %+v, want early first", records)
+	}
+}
+
+func TestServerRecordsScoresAtServerTime(t *testing.T) {
+	t.Setenv("SCORES_FILE", "")
+	s, handles := newTestServer(t, "amy")
+	at := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.SetClock(clock.NewFake(at))
+
+	handles[0].BestScore = 250
+	s.UnregisterClient(handles[0].ID)
+	s.processRegistrations()
+
+	got := s.scores.top()
+	if len(got) != 1 || got[0].Score != 250 || !got[0].At.Equal(at) {
+		t.Errorf("top() = %+v, want amy's 250 at %v", got, at)
+	}
+}
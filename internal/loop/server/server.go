@@ -3,14 +3,19 @@ package server
 import (
 	"cmp"
 	"context"
-	"math/rand"
+	"fmt"
+	"log"
+	"math"
 	"slices"
-	"strings"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/tomz197/asteroids/internal/clock"
+	envconfig "github.com/tomz197/asteroids/internal/config"
 	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/loop/pacing"
 	"github.com/tomz197/asteroids/internal/object"
 	"github.com/tomz197/asteroids/internal/physics"
 )
@@ -19,15 +24,24 @@ import (
 // Decouples the Client from the concrete Server implementation, enabling
 // testing and potential network-based server implementations.
 type GameServer interface {
-	RegisterClient(username string) *ClientHandle
+	RegisterClient(username string, prefs Preferences) *ClientHandle
 	UnregisterClient(clientID int)
 	SendInput(clientID int, input object.Input)
 	SendChatMessage(clientID int, text string)
 	GetSnapshot() *WorldSnapshot
+	GetSnapshotFor(clientID int) *WorldSnapshot
 	GetClientPlayer(clientID int) *object.User
-	SpawnPlayer(clientID int)
+	GetSessionStats(clientID int) SessionStats
+	AllTimeScores() []ScoreRecord
+	SpawnPlayer(clientID int) *object.User
+	SpawnPlayerIn(clientID int, region SpawnRegion) *object.User
+	SetShipShape(clientID int, shape object.ShipShape)
+	SetUsername(clientID int, name string)
+	SetPreferences(clientID int, prefs Preferences)
 	RemovePlayer(clientID int)
 	ResetScore(clientID int)
+	AddRenderBytes(n int)
+	SetPaused(paused bool)
 }
 
 // Server manages the shared world state and processes inputs from all clients.
@@ -37,30 +51,69 @@ type Server struct {
 	clients      map[int]*ClientHandle
 	nextClientID int
 	inputChan    chan ClientInput
-	registerCh   chan *ClientHandle
-	unregisterCh chan int
+	membershipCh chan membershipChange
 	mu           sync.RWMutex
 
-	// Double-buffered snapshot objects to avoid allocations
-	snapshotBufs [2][]object.Object
-	snapshotIdx  int
-
 	// Objects marked for removal (deferred compaction)
 	toRemove map[object.Object]struct{}
 
 	// Reusable player set to avoid per-frame allocation
 	playerSet map[object.Object]struct{}
 
+	// Times ticks; the wall clock unless replaced with SetClock
+	clock clock.Clock
+
+	// Ship deaths this tick, reused to spawn their explosions
+	deathCache []deathSite
+
 	// Chat message ring buffer and mutex
 	chatMessages []ChatMessage
 	chatMu       sync.RWMutex
 	chatChan     chan chatMessageRequest
-	chatDirty    bool           // Set when chatMessages changes; cleared after snapshot copy
-	chatSnapshot []ChatMessage  // Cached snapshot of chat messages
+	chatDirty    bool          // Set when chatMessages changes; cleared after snapshot copy
+	chatSnapshot []ChatMessage // Cached snapshot of chat messages
+
+	// Reusable buffer for the leaderboard (avoids per-frame allocations)
+	topScoresBuf []TopScoreEntry
+
+	// Unlocked achievements per username (optionally persisted)
+	achievements *achievementStore
+
+	// Personal best scores per username (optionally persisted)
+	scores *scoreStore
+
+	asteroidTarget int                     // Weighted asteroid population kept by the spawner
+	asteroidSeed   int                     // Weighted asteroid population seeded at startup
+	spawner        *object.AsteroidSpawner // Keeps the asteroid population; set by Run (nil with waves)
+	waves          bool                    // Asteroids come in waves instead of a steady population (see updateWavesLocked)
+	bounded        bool                    // World edges are walls that objects bounce off, instead of wrapping
+	drag           physics.DragModel       // How velocity decays under drag
+	gravity        float64                 // Pull toward the world center in units/s² (0 = free drift)
+	assistFraction float64                 // Share of an asteroid's score given to its other recent hitters (0 = killer takes all)
+	hugeChance     float64                 // Chance a spawned asteroid is huge (0 = the classic three tiers)
+	respawnNear    bool                    // Ships respawn near their last death (see spawnPositionLocked)
+	waveBreak      float64                 // Seconds since the current wave was cleared
+	explosions     object.ExplosionConfig  // Particle bursts, scaled by PARTICLE_SCALE
+	interest       bool                    // Send each client only the objects around it (INTEREST_MANAGEMENT; see updateViewsLocked)
+	interestLimit  int                     // Objects per client snapshot, by distance (0 = all; see GetSnapshotFor)
+	interestGrid   *physics.SpatialGrid    // Snapshot objects by position, for client views
+	interestDist   []float64               // Reusable per-object distances for updateViewsLocked
+	interestSorted []float64               // Reusable sorted copy of interestDist
+	viewCenters    []viewCenter            // Reusable view centers for updateViewsLocked
+
+	// Operator metrics (see Stats)
+	renderBytes rateCounter // Terminal output bytes rendered for all clients
+	churn       rateCounter // Client registrations plus unregistrations
+	sampledAt   time.Time   // Start of the current rate window (server loop only)
+
+	paused atomic.Bool // World simulation frozen (see SetPaused)
+}
 
-	// Reusable buffers for snapshot creation (avoids per-frame allocations)
-	userObjectsBuf []*object.User
-	topScoresBuf   []TopScoreEntry
+// membershipChange is a pending registration (handle set) or
+// unregistration (clientID set) for the server loop to apply.
+type membershipChange struct {
+	handle   *ClientHandle
+	clientID int
 }
 
 // chatMessageRequest is a request to broadcast a chat message.
@@ -74,15 +127,56 @@ var _ GameServer = (*Server)(nil)
 
 // ClientHandle represents a client's connection to the server.
 type ClientHandle struct {
-	ID                   int
-	Username             string // Display name for this client
-	Player               *object.User
-	Input                object.Input
-	EventsCh             chan ClientEvent // Events sent to client (death, etc.)
-	Score                int              // Current game score (resets on restart)
-	BestScore            int              // Highest score achieved this session (never resets)
-	InvincibleTime       float64          // Remaining invincibility time in seconds
-	RespawnTimeRemaining float64          // Seconds until respawn is allowed (set on death)
+	ID                     int
+	Username               string // Display name for this client
+	Player                 *object.User
+	Input                  object.Input
+	EventsCh               chan ClientEvent              // Events sent to client (death, etc.)
+	Score                  int                           // Current game score (resets on restart)
+	BestScore              int                           // Highest score achieved this session (never resets)
+	InvincibleTime         float64                       // Remaining invincibility time in seconds
+	RespawnTimeRemaining   float64                       // Seconds until respawn is allowed (set on death)
+	Stats                  PlayerStats                   // Shots fired/hit this session (never resets)
+	Kills                  int                           // Players destroyed this session
+	AsteroidsDestroyed     int                           // Asteroids destroyed this session
+	AliveTime              float64                       // Seconds the current ship has survived
+	TimePlayed             float64                       // Seconds spent alive across all ships this session
+	Deaths                 int                           // Ships lost this session
+	Achievements           map[string]bool               // Unlocked achievement IDs (loaded per username)
+	PersonalBest           int                           // Best score in earlier sessions, as of connecting (loaded per username)
+	ShipShape              object.ShipShape              // Silhouette used for this client's ships
+	Prefs                  Preferences                   // Control-feel choices applied to this client's ships
+	LastDeathX, LastDeathY float64                       // Where the last ship died (respawn hint)
+	hasLastDeath           bool                          // LastDeathX/Y are set and not yet used for a respawn
+	view                   atomic.Pointer[WorldSnapshot] // This client's share of the snapshot (see GetSnapshotFor); nil = the full one
+	cameraX, cameraY       atomic.Uint64                 // Last reported camera position, as float64 bits (see SetCamera)
+	hasCamera              atomic.Bool                   // A camera position was reported
+}
+
+// Preferences are a client's control-feel choices. Movement and firing are
+// simulated on the server, so they are applied here, to that client's ships.
+// The zero value keeps the standard controls.
+type Preferences struct {
+	FireMode    object.FireMode // Hold to auto-fire, or one shot per press
+	InstantTurn bool            // Turn at full speed at once instead of ramping up (see config.TurnRamp)
+}
+
+// sanitized returns p with unknown values replaced by the defaults, since
+// preferences come from clients.
+func (p Preferences) sanitized() Preferences {
+	if !p.FireMode.Valid() {
+		p.FireMode = object.FireAuto
+	}
+	return p
+}
+
+// turnRamp returns the turn ramp for ships under these preferences.
+func (p Preferences) turnRamp() object.TurnRamp {
+	ramp := config.TurnRamp
+	if p.InstantTurn {
+		ramp.Time = 0 // Factor is 1 from the first tick
+	}
+	return ramp
 }
 
 // ClientInput represents input from a specific client.
@@ -94,8 +188,12 @@ type ClientInput struct {
 // ClientEvent represents an event sent from server to client.
 type ClientEvent struct {
 	Type     ClientEventType
-	KilledBy string // For death events
-	ScoreAdd int    // For score events
+	KilledBy string      // For death events
+	ScoreAdd int         // For score events
+	Stats    PlayerStats // For death events: the player's stats at time of death
+
+	Achievement string // For achievement events: display name of the unlocked achievement
+	Wave        int    // For wave events: the wave that began
 }
 
 // ClientEventType identifies the type of client event.
@@ -105,48 +203,143 @@ const (
 	EventPlayerDied ClientEventType = iota
 	EventScoreAdd
 	EventServerShutdown
+	EventAchievement
+	EventHyperspace // The player's ship jumped through hyperspace and landed clear
+	EventWave       // A new asteroid wave began
 )
 
 // NewServer creates a new game server.
-func NewServer() *Server {
+// The world is built from the layout file named by WORLD_FILE, or from the
+// built-in defaults when it is unset. An invalid layout file is an error.
+func NewServer() (*Server, error) {
+	worldFile := envconfig.GetEnv("WORLD_FILE", "")
+	layout, err := LoadWorldLayout(worldFile)
+	if err != nil {
+		return nil, err
+	}
+	layout.Bounded = envconfig.GetEnvBool("WORLD_BOUNDED", layout.Bounded)
+	if worldFile != "" {
+		log.Printf("World layout %s: %dx%d, %d asteroids (%d initially), waves %t, bounded %t, %d labels",
+			worldFile, layout.Width, layout.Height, layout.asteroidTarget(), layout.initialAsteroids(), layout.Waves, layout.Bounded, len(layout.Labels))
+	}
+
+	explosions, err := loadExplosionConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	interestLimit, err := loadInterestLimit()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.AsteroidSplit.Validate(); err != nil {
+		return nil, err
+	}
+
 	world := NewWorldState()
+	world.AsteroidSplit = config.AsteroidSplit
 	world.World = object.Screen{
-		Width:   config.WorldWidth,
-		Height:  config.WorldHeight,
-		CenterX: config.WorldWidth / 2,
-		CenterY: config.WorldHeight / 2,
+		Width:   layout.Width,
+		Height:  layout.Height,
+		CenterX: layout.Width / 2,
+		CenterY: layout.Height / 2,
 	}
 	world.Screen = world.World
-	world.InitGrids()
+	world.InitGrids(layout.HugeAsteroids > 0)
+	layout.apply(world)
 
 	s := &Server{
 		world:        world,
 		clients:      make(map[int]*ClientHandle),
 		nextClientID: 1,
 		inputChan:    make(chan ClientInput, 256),
-		registerCh:   make(chan *ClientHandle, 16),
-		unregisterCh: make(chan int, 16),
+		membershipCh: make(chan membershipChange, 64),
 		chatChan:     make(chan chatMessageRequest, 32),
 		toRemove:     make(map[object.Object]struct{}),
 		playerSet:    make(map[object.Object]struct{}),
+		achievements: newAchievementStore(envconfig.GetEnv("ACHIEVEMENTS_FILE", "")),
+		scores:       newScoreStore(envconfig.GetEnv("SCORES_FILE", "")),
+
+		asteroidTarget: layout.asteroidTarget(),
+		asteroidSeed:   layout.initialAsteroids(),
+		waves:          layout.Waves,
+		bounded:        layout.Bounded,
+		drag:           layout.dragModel(),
+		gravity:        layout.Gravity,
+		assistFraction: layout.Assists,
+		hugeChance:     layout.HugeAsteroids,
+		respawnNear:    layout.RespawnNearDeath,
+		explosions:     explosions,
+		interest:       envconfig.GetEnvBool("INTEREST_MANAGEMENT", false),
+		interestLimit:  interestLimit,
+		interestGrid:   physics.NewSpatialGrid(float64(layout.Width), float64(layout.Height), config.InterestCellSize),
+		clock:          clock.Real,
 	}
 
 	// Create initial empty snapshot
 	s.snapshot.Store(&WorldSnapshot{
 		Objects:      []object.Object{},
 		World:        world.World,
+		Bounded:      s.bounded,
 		ChatMessages: []ChatMessage{},
+		Difficulty:   1,
 	})
 
-	return s
+	return s, nil
+}
+
+// loadExplosionConfig returns config.Explosions scaled by the PARTICLE_SCALE
+// env var (default 1; 0 disables explosion particles).
+func loadExplosionConfig() (object.ExplosionConfig, error) {
+	scale := 1.0
+	if v := envconfig.GetEnv("PARTICLE_SCALE", ""); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+			return object.ExplosionConfig{}, fmt.Errorf("invalid PARTICLE_SCALE %q: must be a non-negative number", v)
+		}
+		scale = f
+	}
+	explosions := config.Explosions.Scaled(scale)
+	if err := explosions.Validate(); err != nil {
+		return object.ExplosionConfig{}, err
+	}
+	return explosions, nil
+}
+
+// SetClock replaces the clock that times server ticks; nil restores the wall
+// clock. Call it before Run. Ticks are still paced in real time, so with a
+// fake clock each tick advances the world by however far the clock was moved.
+func (s *Server) SetClock(c clock.Clock) {
+	s.clock = clock.Or(c)
+}
+
+// addSpawners adds the asteroid spawner, unless asteroids come in waves, and
+// the power-up spawner to the world.
+func (s *Server) addSpawners() {
+	if !s.waves {
+		s.spawner = object.NewAsteroidSpawner(object.AsteroidSpawnerConfig{
+			Initial: s.asteroidSeed,
+			Target:  s.asteroidTarget,
+			Ramp:    config.AsteroidRampRate,
+			Refill:  config.AsteroidRefillRate,
+
+			HugeChance: s.hugeChance,
+		})
+		s.world.AddObject(s.spawner)
+	}
+	s.world.AddObject(&object.PowerUpSpawner{
+		Interval: config.PowerUpInterval.Seconds(),
+		Max:      config.MaxPowerUps,
+		Lifetime: config.PowerUpLifetime.Seconds(),
+	})
 }
 
 // Run starts the server loop. Blocks until the context is cancelled.
 func (s *Server) Run(ctx context.Context) {
-	lastTime := time.Now()
-
-	// Add asteroid spawner
-	s.world.AddObject(object.NewAsteroidSpawner(config.InitialAsteroidTarget))
+	lastTime := s.clock.Now()
+	s.addSpawners()
+	pacer := pacing.New(config.ServerTickTime)
 
 	for {
 		select {
@@ -155,8 +348,8 @@ func (s *Server) Run(ctx context.Context) {
 		default:
 		}
 
-		frameStart := time.Now()
-		s.world.Delta = frameStart.Sub(lastTime)
+		frameStart := s.clock.Now()
+		s.world.Delta = min(frameStart.Sub(lastTime), config.MaxTickDelta)
 		lastTime = frameStart
 
 		// Process registrations/unregistrations
@@ -169,16 +362,17 @@ func (s *Server) Run(ctx context.Context) {
 		s.collectInputs()
 
 		// Update world state
-		s.updateWorld()
+		if !s.paused.Load() {
+			s.updateWorld()
+		}
 
 		// Create new snapshot for clients
 		s.createSnapshot()
 
+		s.sampleRates(frameStart)
+
 		// Frame timing
-		elapsed := time.Since(frameStart)
-		if elapsed < config.ServerTickTime {
-			time.Sleep(config.ServerTickTime - elapsed)
-		}
+		pacer.Wait()
 	}
 }
 
@@ -200,6 +394,7 @@ func (s *Server) Shutdown(timeout time.Duration) {
 	deadline := time.After(timeout)
 	ticker := time.NewTicker(200 * time.Millisecond)
 	defer ticker.Stop()
+	defer s.saveScores()
 
 	for {
 		select {
@@ -216,26 +411,42 @@ func (s *Server) Shutdown(timeout time.Duration) {
 	}
 }
 
+// saveScores records the scores of players still connected and writes the
+// score file, so high scores survive the restart that follows Shutdown.
+func (s *Server) saveScores() {
+	s.mu.RLock()
+	for _, handle := range s.clients {
+		s.scores.record(handle.Username, handle.BestScore, s.clock.Now())
+	}
+	s.mu.RUnlock()
+	s.scores.save()
+}
+
 // RegisterClient registers a new client with the given username and returns its handle.
-func (s *Server) RegisterClient(username string) *ClientHandle {
+func (s *Server) RegisterClient(username string, prefs Preferences) *ClientHandle {
 	s.mu.Lock()
 	id := s.nextClientID
 	s.nextClientID++
 	s.mu.Unlock()
 
 	handle := &ClientHandle{
-		ID:       id,
-		Username: username,
-		EventsCh: make(chan ClientEvent, 16),
+		ID:           id,
+		Username:     username,
+		EventsCh:     make(chan ClientEvent, 16),
+		Achievements: s.achievements.forUser(username),
+		PersonalBest: s.scores.best(username),
+		Prefs:        prefs.sanitized(),
 	}
 
-	s.registerCh <- handle
+	s.membershipCh <- membershipChange{handle: handle}
+	s.churn.total.Add(1)
 	return handle
 }
 
 // UnregisterClient removes a client from the server.
 func (s *Server) UnregisterClient(clientID int) {
-	s.unregisterCh <- clientID
+	s.membershipCh <- membershipChange{clientID: clientID}
+	s.churn.total.Add(1)
 }
 
 // SendInput sends input from a client to the server.
@@ -247,15 +458,13 @@ func (s *Server) SendInput(clientID int, input object.Input) {
 	}
 }
 
-// SendChatMessage broadcasts a chat message from a client to all connected clients.
+// SendChatMessage broadcasts a chat message from a client to all connected
+// clients, after sanitizing it (see SanitizeChatMessage).
 func (s *Server) SendChatMessage(clientID int, text string) {
-	text = strings.TrimSpace(text)
+	text = SanitizeChatMessage(text)
 	if text == "" {
 		return
 	}
-	if len(text) > config.MaxChatMessageLength {
-		text = text[:config.MaxChatMessageLength]
-	}
 	select {
 	case s.chatChan <- chatMessageRequest{clientID: clientID, text: text}:
 	default:
@@ -268,29 +477,85 @@ func (s *Server) GetSnapshot() *WorldSnapshot {
 	return s.snapshot.Load()
 }
 
-// GetClientPlayer returns the player object for a client (thread-safe).
+// GetClientPlayer returns a copy of the client's ship taken under the lock,
+// or nil if it has none. The copy is safe to read while the server keeps
+// updating the live ship.
 func (s *Server) GetClientPlayer(clientID int) *object.User {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	if handle, ok := s.clients[clientID]; ok {
-		return handle.Player
+	if handle, ok := s.clients[clientID]; ok && handle.Player != nil {
+		player := *handle.Player
+		return &player
 	}
 	return nil
 }
 
-// SpawnPlayer spawns a player for the given client.
-func (s *Server) SpawnPlayer(clientID int) {
+// AllTimeScores returns the all-time high score list, best first
+// (thread-safe). The slice is shared and must not be modified.
+func (s *Server) AllTimeScores() []ScoreRecord {
+	return s.scores.top()
+}
+
+// GetSessionStats returns the session summary for a client (thread-safe).
+// Returns zero stats for unknown clients.
+func (s *Server) GetSessionStats(clientID int) SessionStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	handle, ok := s.clients[clientID]
+	if !ok {
+		return SessionStats{}
+	}
+	rank := 1
+	for _, h := range s.clients {
+		if h.BestScore > handle.BestScore {
+			rank++
+		}
+	}
+	return SessionStats{
+		PlayerStats:        handle.Stats,
+		TimePlayed:         handle.TimePlayed,
+		BestScore:          handle.BestScore,
+		AsteroidsDestroyed: handle.AsteroidsDestroyed,
+		Kills:              handle.Kills,
+		Deaths:             handle.Deaths,
+		Rank:               rank,
+		Players:            len(s.clients),
+	}
+}
+
+// SetPaused freezes or resumes the world simulation. Clients stay connected
+// and keep receiving (unchanging) snapshots. Meant for a private
+// single-player server; pausing a shared world pauses it for everyone.
+func (s *Server) SetPaused(paused bool) {
+	s.paused.Store(paused)
+}
+
+// SpawnPlayer spawns a player for the given client anywhere in the world and
+// returns a copy of the new ship (see SpawnPlayerIn).
+func (s *Server) SpawnPlayer(clientID int) *object.User {
+	return s.SpawnPlayerIn(clientID, SpawnRegion{})
+}
+
+// SpawnPlayerIn is SpawnPlayer restricted to a world region, still subject
+// to the usual clearance checks. An empty region, or one entirely outside
+// the world, spawns anywhere.
+//
+// Returns a copy of the new ship taken under the same lock as the spawn, so
+// the caller sees the ship as spawned even if the server destroys it before
+// the caller could ask for it separately. Returns nil if no ship was spawned
+// (unknown client or respawn timeout still running).
+func (s *Server) SpawnPlayerIn(clientID int, region SpawnRegion) *object.User {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	handle, ok := s.clients[clientID]
 	if !ok {
-		return
+		return nil
 	}
 
 	// Enforce respawn timeout
 	if handle.RespawnTimeRemaining > 0 {
-		return
+		return nil
 	}
 
 	// Remove existing player if any
@@ -298,15 +563,67 @@ func (s *Server) SpawnPlayer(clientID int) {
 		s.removeObjectLocked(handle.Player)
 	}
 
-	// Create new player at random location
-	x := rand.Float64() * float64(config.WorldWidth)
-	y := rand.Float64() * float64(config.WorldHeight)
+	// Create new player at a clear spot (near the last death if enabled)
+	x, y := s.spawnPositionLocked(handle, region)
+	handle.hasLastDeath = false
 	player := object.NewUser(x, y)
 	player.OwnerID = clientID
 	player.Username = handle.Username
+	player.Shape = handle.ShipShape
+	player.Color = uint8(clientID % object.ShipColorCount)
+	player.Pierce = config.ProjectilePierce
 	handle.Player = player
 	handle.InvincibleTime = config.InvincibilityTime.Seconds()
+	handle.AliveTime = 0
 	s.world.AddObject(player)
+
+	spawned := *player
+	return &spawned
+}
+
+// SetShipShape sets the silhouette used for the client's future ships.
+func (s *Server) SetShipShape(clientID int, shape object.ShipShape) {
+	if shape < 0 || int(shape) >= object.ShipShapeCount {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if handle, ok := s.clients[clientID]; ok {
+		handle.ShipShape = shape
+	}
+}
+
+// SetUsername renames the client after sanitizing the name (see
+// SanitizeUsername), loading the achievements and personal best saved for
+// it. Names that sanitize to nothing are ignored.
+func (s *Server) SetUsername(clientID int, name string) {
+	name = SanitizeUsername(name)
+	if name == "" {
+		return
+	}
+	achievements := s.achievements.forUser(name)
+	best := s.scores.best(name)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if handle, ok := s.clients[clientID]; ok {
+		handle.Username = name
+		handle.Achievements = achievements
+		handle.PersonalBest = best
+		if handle.Player != nil {
+			handle.Player.Username = name
+		}
+	}
+}
+
+// SetPreferences replaces the client's control preferences, after
+// sanitizing them (see Preferences.sanitized).
+func (s *Server) SetPreferences(clientID int, prefs Preferences) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if handle, ok := s.clients[clientID]; ok {
+		handle.Prefs = prefs.sanitized()
+	}
 }
 
 // RemovePlayer removes the player for a client.
@@ -330,6 +647,7 @@ func (s *Server) ResetScore(clientID int) {
 	defer s.mu.Unlock()
 	if handle, ok := s.clients[clientID]; ok {
 		handle.Score = 0
+		handle.hasLastDeath = false // Full restart: no respawn-near-death hint
 	}
 }
 
@@ -348,23 +666,25 @@ func (s *Server) removeObjectLocked(target object.Object) {
 	}
 }
 
-// processRegistrations handles pending client registrations/unregistrations.
+// processRegistrations applies pending registrations and unregistrations.
+// They share one channel so they are applied in the order they were made: a
+// client that connects and drops within one tick is added, then removed,
+// rather than possibly removed first and left behind as a ghost entry.
 func (s *Server) processRegistrations() {
 	for {
 		select {
-		case handle := <-s.registerCh:
+		case change := <-s.membershipCh:
 			s.mu.Lock()
-			s.clients[handle.ID] = handle
-			s.mu.Unlock()
-		case clientID := <-s.unregisterCh:
-			s.mu.Lock()
-			if handle, ok := s.clients[clientID]; ok {
+			if change.handle != nil {
+				s.clients[change.handle.ID] = change.handle
+			} else if handle, ok := s.clients[change.clientID]; ok {
 				// Remove player from world
 				if handle.Player != nil {
 					s.removeObjectLocked(handle.Player)
 				}
+				s.scores.record(handle.Username, handle.BestScore, s.clock.Now())
 				close(handle.EventsCh)
-				delete(s.clients, clientID)
+				delete(s.clients, change.clientID)
 			}
 			s.mu.Unlock()
 		default:
@@ -385,7 +705,7 @@ func (s *Server) processChatMessages() {
 			}
 			s.mu.RUnlock()
 
-			msg := ChatMessage{Username: username, Text: req.text}
+			msg := ChatMessage{Username: username, Text: req.text, At: s.clock.Now()}
 
 			s.chatMu.Lock()
 			s.chatMessages = append(s.chatMessages, msg)
@@ -411,6 +731,10 @@ func (s *Server) collectInputs() {
 		select {
 		case ci := <-s.inputChan:
 			if handle, ok := s.clients[ci.ClientID]; ok {
+				// A fire press must survive being replaced by a later input
+				// before a tick consumes it
+				ci.Input.FirePress = ci.Input.FirePress || handle.Input.FirePress
+				ci.Input.Hyperspace = ci.Input.Hyperspace || handle.Input.Hyperspace
 				handle.Input = ci.Input
 			}
 		default:
@@ -432,6 +756,8 @@ func (s *Server) updateWorld() {
 	for _, handle := range s.clients {
 		if handle.Player != nil {
 			s.playerSet[handle.Player] = struct{}{}
+			handle.AliveTime += dt
+			handle.TimePlayed += dt
 		}
 		if handle.InvincibleTime > 0 {
 			handle.InvincibleTime -= dt
@@ -447,6 +773,10 @@ func (s *Server) updateWorld() {
 		}
 	}
 
+	if s.waves {
+		s.updateWavesLocked(dt)
+	}
+
 	// Update each player with their input
 	for _, handle := range s.clients {
 		if handle.Player != nil {
@@ -457,12 +787,26 @@ func (s *Server) updateWorld() {
 				Spawner:       s.world,
 				Objects:       s.world.Objects,
 				AsteroidCount: s.world.AsteroidCount,
+				DragModel:     s.drag,
+				Explosions:    s.explosions,
+				AsteroidSplit: s.world.AsteroidSplit,
+				Bounded:       s.bounded,
+				Gravity:       s.gravity,
+				TurnRamp:      handle.Prefs.turnRamp(),
+				FireMode:      handle.Prefs.FireMode,
+				Hyperspace:    config.Hyperspace,
 			}
 			remove, _ := handle.Player.Update(ctx)
+			if jumped, safe := handle.Player.Hyperspaced(); jumped && safe {
+				s.rematerializeLocked(handle)
+			}
 			if remove {
 				handle.Player = nil
 			}
 		}
+		// Consumed, or dropped while dead
+		handle.Input.FirePress = false
+		handle.Input.Hyperspace = false
 	}
 
 	// Update non-player objects with empty input
@@ -474,6 +818,11 @@ func (s *Server) updateWorld() {
 		Spawner:       s.world,
 		Objects:       s.world.Objects,
 		AsteroidCount: s.world.AsteroidCount,
+		DragModel:     s.drag,
+		Explosions:    s.explosions,
+		AsteroidSplit: s.world.AsteroidSplit,
+		Bounded:       s.bounded,
+		Gravity:       s.gravity,
 	}
 
 	kept := s.world.Objects[:0]
@@ -494,10 +843,32 @@ func (s *Server) updateWorld() {
 		}
 	}
 	s.world.Objects = kept
+
+	// Credit newly fired projectiles to their owners before they join the world
+	for _, obj := range s.world.toSpawn {
+		if p, ok := obj.(*object.Projectile); ok {
+			if h, ok := s.clients[p.OwnerID]; ok {
+				h.Stats.ShotsFired++
+			}
+		}
+	}
 	s.world.FlushSpawned()
 
 	// Check collisions
 	s.checkCollisions()
+
+	// Unlock achievements reached this tick
+	s.checkAchievementsLocked()
+}
+
+// rematerializeLocked makes a ship that just landed from a safe hyperspace
+// jump briefly invincible, and tells its client so it blinks the ship.
+func (s *Server) rematerializeLocked(handle *ClientHandle) {
+	handle.InvincibleTime = max(handle.InvincibleTime, config.HyperspaceInvincible.Seconds())
+	select {
+	case handle.EventsCh <- ClientEvent{Type: EventHyperspace}:
+	default:
+	}
 }
 
 // checkCollisions detects and handles collisions using spatial grids
@@ -505,7 +876,7 @@ func (s *Server) updateWorld() {
 // cells are checked against each other, reducing O(n^2) to ~O(n).
 func (s *Server) checkCollisions() {
 	// Extract collidables and populate spatial grids
-	collectCollidables(s.world.Objects, &s.world.projectileCache, &s.world.asteroidCache)
+	collectCollidables(s.world.Objects, &s.world.projectileCache, &s.world.asteroidCache, &s.world.powerUpCache)
 	projectiles := s.world.projectileCache
 	asteroids := s.world.asteroidCache
 	populateGrids(asteroids, projectiles, s.world.asteroidGrid, s.world.projectileGrid)
@@ -524,22 +895,31 @@ func (s *Server) checkCollisions() {
 				return false
 			}
 			if physics.PointInCircle(p.X, p.Y, a.X, a.Y, a.GetRadius()) {
-				p.MarkDestroyed()
+				// Piercing shots fly on through small asteroids
+				pierced := a.Size == object.AsteroidSmall && p.Pierce > 0
+				if pierced {
+					p.Pierce--
+				} else {
+					p.MarkDestroyed()
+				}
 				a.MarkDestroyed()
+				s.explosions.Impact.Spawn(p.X, p.Y, s.world)
 
-				// Award score to the client that owns this projectile
+				// Award score to the client that owns this projectile; a
+				// piercing shot scores every asteroid but is one hit
 				if handle, ok := s.clients[p.OwnerID]; ok {
-					add := asteroidScore(a.Size)
-					handle.Score += add
-					if handle.Score > handle.BestScore {
-						handle.BestScore = handle.Score
-					}
-					select {
-					case handle.EventsCh <- ClientEvent{Type: EventScoreAdd, ScoreAdd: add}:
-					default:
+					if !p.Hit {
+						handle.Stats.ShotsHit++
 					}
+					handle.AsteroidsDestroyed++
+					awardScore(handle, asteroidScore(a.Size))
+				}
+				p.Hit = true
+				if s.assistFraction > 0 {
+					s.awardAssists(a, p.OwnerID)
+					a.RecordHit(p.OwnerID, config.AssistWindow.Seconds())
 				}
-				return true // Projectile destroyed, stop checking
+				return !pierced // Stop checking once the projectile is spent
 			}
 			return false
 		})
@@ -551,6 +931,9 @@ func (s *Server) checkCollisions() {
 	// Asteroid-asteroid collisions (bouncing)
 	checkAsteroidAsteroidCollisions(asteroids, s.world.asteroidGrid)
 
+	// Power-up pickups (invincible ships collect them too)
+	s.collectPowerUps(s.world.powerUpCache)
+
 	// Player collisions (skip invincible players)
 	for _, handle := range s.clients {
 		if handle.Player == nil || handle.InvincibleTime > 0 {
@@ -561,7 +944,8 @@ func (s *Server) checkCollisions() {
 		ownerID := handle.ID
 
 		hit := false
-		killerID := -1 // -1 means killed by asteroid or other, not another player
+		killerID := -1       // -1 means killed by asteroid or other, not another player
+		shotCounted := false // The killing shot already counted as a hit on an asteroid it pierced
 
 		// Check projectile hits via projectile grid (skip own projectiles)
 		s.world.projectileGrid.QueryAround(px, py, func(pi int) bool {
@@ -573,6 +957,7 @@ func (s *Server) checkCollisions() {
 				p.MarkDestroyed()
 				hit = true
 				killerID = p.OwnerID
+				shotCounted, p.Hit = p.Hit, true
 				return true // Found a hit, stop checking
 			}
 			return false
@@ -599,25 +984,25 @@ func (s *Server) checkCollisions() {
 			if killerID >= 0 {
 				if h, ok := s.clients[killerID]; ok {
 					killerHandle = h
-					killerHandle.Score += config.ScorePlayerKill
-					if killerHandle.Score > killerHandle.BestScore {
-						killerHandle.BestScore = killerHandle.Score
-					}
-					select {
-					case killerHandle.EventsCh <- ClientEvent{Type: EventScoreAdd, ScoreAdd: config.ScorePlayerKill}:
-					default:
+					if !shotCounted {
+						killerHandle.Stats.ShotsHit++
 					}
+					killerHandle.Kills++
+					awardScore(killerHandle, config.ScorePlayerKill)
 				}
 			}
 
-			// Spawn death explosion
+			// Queue death explosion; spawned below once the tick's death count is known
 			x, y := handle.Player.GetPosition()
-			object.SpawnExplosion(x, y, 20, 25.0, 1.0, s.world)
+			s.deathCache = append(s.deathCache, deathSite{x: x, y: y})
+			handle.LastDeathX, handle.LastDeathY, handle.hasLastDeath = x, y, true
 
 			// Mark player for removal (deferred compaction)
 			s.toRemove[handle.Player] = struct{}{}
 			handle.Player = nil
 			handle.RespawnTimeRemaining = config.RespawnTimeout.Seconds()
+			handle.Deaths++
+			s.scores.record(handle.Username, handle.BestScore, s.clock.Now())
 
 			// Notify client (include killer username when killed by another player)
 			killedBy := ""
@@ -625,12 +1010,14 @@ func (s *Server) checkCollisions() {
 				killedBy = killerHandle.Username
 			}
 			select {
-			case handle.EventsCh <- ClientEvent{Type: EventPlayerDied, KilledBy: killedBy}:
+			case handle.EventsCh <- ClientEvent{Type: EventPlayerDied, KilledBy: killedBy, Stats: handle.Stats}:
 			default:
 			}
 		}
 	}
 
+	s.spawnDeathExplosions()
+
 	// Perform deferred compaction if needed
 	if len(s.toRemove) > 0 {
 		kept := s.world.Objects[:0]
@@ -645,33 +1032,60 @@ func (s *Server) checkCollisions() {
 	}
 }
 
+// collectPowerUps gives each pickup a ship touches to that ship. There are
+// only a handful of pickups, so every ship is checked against each of them.
+func (s *Server) collectPowerUps(powerUps []*object.PowerUp) {
+	if len(powerUps) == 0 {
+		return
+	}
+	for _, handle := range s.clients {
+		ship := handle.Player
+		if ship == nil {
+			continue
+		}
+		for _, p := range powerUps {
+			if p.IsCollected() {
+				continue
+			}
+			if physics.CirclesOverlap(ship.X, ship.Y, ship.GetRadius(), p.X, p.Y, p.GetRadius()) {
+				p.MarkCollected()
+				ship.ApplyPowerUp(p.Kind, config.PowerUpDuration.Seconds())
+				s.explosions.Impact.Spawn(p.X, p.Y, s.world)
+			}
+		}
+	}
+}
+
+// deathSite is where a ship died during the current tick.
+type deathSite struct {
+	x, y float64
+}
+
+// spawnDeathExplosions spawns the explosions for this tick's deaths. If the
+// full bursts would exceed config.DeathParticleBudget, every explosion is
+// shrunk evenly (down to config.MinDeathParticles) instead of dropping any.
+func (s *Server) spawnDeathExplosions() {
+	n := len(s.deathCache)
+	if n == 0 {
+		return
+	}
+	burst := s.explosions.ShipDeath
+	if burst.Count*n > config.DeathParticleBudget {
+		burst.Count = max(config.DeathParticleBudget/n, min(config.MinDeathParticles, burst.Count))
+	}
+	for _, d := range s.deathCache {
+		burst.Spawn(d.x, d.y, s.world)
+	}
+	s.deathCache = s.deathCache[:0]
+}
+
 // createSnapshot creates an immutable snapshot of the world state.
 func (s *Server) createSnapshot() {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// Use double-buffered slice to avoid allocations
-	idx := s.snapshotIdx
-	s.snapshotIdx = 1 - s.snapshotIdx // Toggle for next frame
-
-	// Grow buffer if needed, otherwise reuse
-	buf := s.snapshotBufs[idx]
-	if cap(buf) < len(s.world.Objects) {
-		buf = make([]object.Object, len(s.world.Objects))
-		s.snapshotBufs[idx] = buf
-	}
-	buf = buf[:len(s.world.Objects)]
-	copy(buf, s.world.Objects)
-
-	// Build user objects list using reusable buffer
-	s.userObjectsBuf = s.userObjectsBuf[:0]
-	for _, obj := range buf {
-		if user, ok := obj.(*object.User); ok {
-			s.userObjectsBuf = append(s.userObjectsBuf, user)
-		}
-	}
-	usersCopy := make([]*object.User, len(s.userObjectsBuf))
-	copy(usersCopy, s.userObjectsBuf)
+	// Value copies: clients render them while the next tick runs
+	objects, users := object.CopyObjects(s.world.Objects)
 
 	// Build top scores leaderboard
 	topScores := s.buildTopScoresLocked()
@@ -687,16 +1101,30 @@ func (s *Server) createSnapshot() {
 	s.chatMu.RUnlock()
 
 	snapshot := &WorldSnapshot{
-		Objects:      buf,
-		UserObjects:  usersCopy,
+		Objects:      objects,
+		UserObjects:  users,
 		Players:      len(s.clients),
 		World:        s.world.World,
+		Bounded:      s.bounded,
 		Delta:        s.world.Delta,
 		TopScores:    topScores,
 		ChatMessages: chatMessages,
+		Difficulty:   s.difficultyLocked(),
+		Wave:         s.world.Wave,
 	}
 
 	s.snapshot.Store(snapshot)
+	s.updateViewsLocked(snapshot)
+}
+
+// difficultyLocked returns the asteroid population the spawner currently
+// maintains relative to the world's target (1 once any ramp has finished).
+// Must be called with s.mu held.
+func (s *Server) difficultyLocked() float64 {
+	if s.spawner == nil || s.asteroidTarget <= 0 {
+		return 1
+	}
+	return s.spawner.Level() / float64(s.asteroidTarget)
 }
 
 // buildTopScoresLocked builds the top N scores from connected clients.
@@ -711,13 +1139,13 @@ func (s *Server) buildTopScoresLocked() []TopScoreEntry {
 		if name == "" {
 			name = "(anon)"
 		}
-		s.topScoresBuf = append(s.topScoresBuf, TopScoreEntry{Username: name, Score: h.BestScore, clientID: h.ID})
+		s.topScoresBuf = append(s.topScoresBuf, TopScoreEntry{Username: name, Score: h.BestScore, Stats: h.Stats, ClientID: h.ID})
 	}
 	slices.SortFunc(s.topScoresBuf, func(a, b TopScoreEntry) int {
 		if c := cmp.Compare(b.Score, a.Score); c != 0 {
 			return c
 		}
-		return cmp.Compare(a.clientID, b.clientID)
+		return cmp.Compare(a.ClientID, b.ClientID)
 	})
 	n := config.TopScoresCount
 	if n > len(s.topScoresBuf) {
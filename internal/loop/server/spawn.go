@@ -0,0 +1,149 @@
+package server
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// SpawnRegion is a rectangle of the world a client asks to spawn in, for
+// practice or scripted scenarios. The zero value means anywhere.
+type SpawnRegion struct {
+	X, Y          float64 // Top-left corner in world coordinates
+	Width, Height float64
+}
+
+// Quadrant is a quarter of the world a client can ask to spawn in.
+type Quadrant int
+
+const (
+	QuadrantNone        Quadrant = iota // Anywhere in the world
+	QuadrantTopLeft                     // "nw"
+	QuadrantTopRight                    // "ne"
+	QuadrantBottomLeft                  // "sw"
+	QuadrantBottomRight                 // "se"
+)
+
+// quadrantNames holds the name of each Quadrant, indexed by quadrant.
+var quadrantNames = [...]string{
+	QuadrantNone:        "",
+	QuadrantTopLeft:     "nw",
+	QuadrantTopRight:    "ne",
+	QuadrantBottomLeft:  "sw",
+	QuadrantBottomRight: "se",
+}
+
+// QuadrantByName returns the quadrant with the given name ("nw", "ne", "sw"
+// or "se"; "" is QuadrantNone), or QuadrantNone and false if none matches.
+func QuadrantByName(name string) (Quadrant, bool) {
+	for i, n := range quadrantNames {
+		if n == name {
+			return Quadrant(i), true
+		}
+	}
+	return QuadrantNone, false
+}
+
+// QuadrantRegion returns quadrant q of the world. QuadrantNone and unknown
+// values return the zero region, which means anywhere.
+func QuadrantRegion(world object.Screen, q Quadrant) SpawnRegion {
+	if q <= QuadrantNone || q > QuadrantBottomRight {
+		return SpawnRegion{}
+	}
+	i := int(q - QuadrantTopLeft)
+	w, h := float64(world.Width)/2, float64(world.Height)/2
+	return SpawnRegion{X: float64(i%2) * w, Y: float64(i/2) * h, Width: w, Height: h}
+}
+
+// clip returns r limited to the world, and false if nothing is left (or r
+// is the zero region), in which case the whole world should be used.
+func (r SpawnRegion) clip(world object.Screen) (SpawnRegion, bool) {
+	x0, y0 := max(r.X, 0), max(r.Y, 0)
+	x1 := min(r.X+r.Width, float64(world.Width))
+	y1 := min(r.Y+r.Height, float64(world.Height))
+	if x1 <= x0 || y1 <= y0 {
+		return SpawnRegion{}, false
+	}
+	return SpawnRegion{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0}, true
+}
+
+// spawnPositionLocked picks where handle's next ship appears. A requested
+// region (clipped to the world) takes precedence; otherwise, in a world with
+// respawn_near_death and with a stored death location, candidates are drawn
+// within config.RespawnNearRadius of it; otherwise from the whole world.
+// Up to config.SpawnAttempts candidates are sampled and the first with no
+// asteroid or ship within config.SpawnClearance wins; if none is clear, the
+// candidate with the most room is used. Caller must hold s.mu.
+func (s *Server) spawnPositionLocked(handle *ClientHandle, region SpawnRegion) (x, y float64) {
+	world := s.world.World
+	region, inRegion := region.clip(world)
+	near := !inRegion && s.respawnNear && handle.hasLastDeath
+
+	bestRoom := math.Inf(-1)
+	for i := 0; i < config.SpawnAttempts; i++ {
+		var cx, cy float64
+		if inRegion {
+			cx = region.X + rand.Float64()*region.Width
+			cy = region.Y + rand.Float64()*region.Height
+		} else if near {
+			angle := rand.Float64() * 2 * math.Pi
+			dist := math.Sqrt(rand.Float64()) * config.RespawnNearRadius // Uniform over the disc
+			cx = handle.LastDeathX + math.Cos(angle)*dist
+			cy = handle.LastDeathY + math.Sin(angle)*dist
+			world.WrapPosition(&cx, &cy)
+		} else {
+			cx = rand.Float64() * float64(world.Width)
+			cy = rand.Float64() * float64(world.Height)
+		}
+
+		room := s.spawnRoomLocked(cx, cy)
+		if room > bestRoom {
+			bestRoom, x, y = room, cx, cy
+		}
+		if room >= config.SpawnClearance {
+			break
+		}
+	}
+	return x, y
+}
+
+// spawnRoomLocked returns the free distance around (x, y): the gap to the
+// nearest asteroid edge or ship, measured across world wrap.
+func (s *Server) spawnRoomLocked(x, y float64) float64 {
+	room := math.Inf(1)
+	for _, obj := range s.world.Objects {
+		var ox, oy, r float64
+		switch o := obj.(type) {
+		case *object.Asteroid:
+			if o.IsDestroyed() {
+				continue
+			}
+			ox, oy, r = o.X, o.Y, o.GetRadius()
+		case *object.User:
+			ox, oy, r = o.X, o.Y, o.GetRadius()
+		default:
+			continue
+		}
+		dx := wrapDelta(ox-x, float64(s.world.World.Width))
+		dy := wrapDelta(oy-y, float64(s.world.World.Height))
+		room = min(room, math.Sqrt(dx*dx+dy*dy)-r)
+	}
+	return room
+}
+
+// wrapDelta returns the shortest signed distance for d in a wrapping world
+// of the given size.
+func wrapDelta(d, size float64) float64 {
+	if size <= 0 {
+		return d
+	}
+	d = math.Mod(d, size)
+	if d > size/2 {
+		d -= size
+	} else if d < -size/2 {
+		d += size
+	}
+	return d
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+func TestQuadrantByName(t *testing.T) {
+	tests := []struct {
+		name string
+		want Quadrant
+		ok   bool
+	}{
+		{"", QuadrantNone, true},
+		{"nw", QuadrantTopLeft, true},
+		{"ne", QuadrantTopRight, true},
+		{"sw", QuadrantBottomLeft, true},
+		{"se", QuadrantBottomRight, true},
+		{"north", QuadrantNone, false},
+	}
+	for _, tt := range tests {
+		got, ok := QuadrantByName(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("QuadrantByName(%q) = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestQuadrantRegion(t *testing.T) {
+	world := object.Screen{Width: 400, Height: 200}
+	tests := []struct {
+		q    Quadrant
+		want SpawnRegion
+	}{
+		{QuadrantNone, SpawnRegion{}},
+		{QuadrantTopLeft, SpawnRegion{X: 0, Y: 0, Width: 200, Height: 100}},
+		{QuadrantTopRight, SpawnRegion{X: 200, Y: 0, Width: 200, Height: 100}},
+		{QuadrantBottomLeft, SpawnRegion{X: 0, Y: 100, Width: 200, Height: 100}},
+		{QuadrantBottomRight, SpawnRegion{X: 200, Y: 100, Width: 200, Height: 100}},
+		{Quadrant(9), SpawnRegion{}},
+	}
+	for _, tt := range tests {
+		if got := QuadrantRegion(world, tt.q); got != tt.want {
+			t.Errorf("QuadrantRegion(%v) = %+v, want %+v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestSpawnPlayerInRegion(t *testing.T) {
+	s, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	h := s.RegisterClient("pilot", Preferences{})
+	s.processRegistrations()
+
+	for q := QuadrantTopLeft; q <= QuadrantBottomRight; q++ {
+		region := QuadrantRegion(s.world.World, q)
+		for i := 0; i < 20; i++ {
+			p := s.SpawnPlayerIn(h.ID, region)
+			if p == nil {
+				t.Fatalf("SpawnPlayerIn returned nil")
+			}
+			if p.X < region.X || p.X >= region.X+region.Width || p.Y < region.Y || p.Y >= region.Y+region.Height {
+				t.Errorf("quadrant %v: spawned at (%.1f, %.1f), outside %+v", q, p.X, p.Y, region)
+			}
+			s.RemovePlayer(h.ID)
+		}
+	}
+}
+
+// TestSpawnPlayerInShipDiesAtOnce destroys a ship on the first tick after it
+// spawns: the copy SpawnPlayerIn returned must stay usable even though the
+// live ship is gone.
+func TestSpawnPlayerInShipDiesAtOnce(t *testing.T) {
+	s, handles := newTestServer(t, "pilot")
+	h := handles[0]
+	spawned := s.SpawnPlayerIn(h.ID, SpawnRegion{})
+	if spawned == nil {
+		t.Fatal("SpawnPlayerIn spawned nothing")
+	}
+	if spawned == h.Player {
+		t.Fatal("SpawnPlayerIn returned the live ship, not a copy")
+	}
+	x, y := spawned.X, spawned.Y
+
+	// Strip the spawn invincibility and park an asteroid on the ship
+	h.InvincibleTime = 0
+	addStillAsteroid(s, h.Player.X, h.Player.Y, object.AsteroidLarge)
+	tick(s)
+
+	if spawned.OwnerID != h.ID || spawned.Username != "pilot" || spawned.X != x || spawned.Y != y {
+		t.Errorf("returned ship changed after the live one died: %+v", spawned)
+	}
+	if p := s.GetClientPlayer(h.ID); p != nil {
+		t.Errorf("GetClientPlayer = %+v after the ship died, want nil", p)
+	}
+	died := false
+	for _, e := range drainEvents(h) {
+		died = died || e.Type == EventPlayerDied
+	}
+	if !died {
+		t.Error("no EventPlayerDied for the ship")
+	}
+}
+
+// TestRespawnNearDeath destroys a ship and respawns it repeatedly: with
+// respawn_near_death every respawn lands within config.RespawnNearRadius of
+// the death, and without it respawns range over the whole world.
+func TestRespawnNearDeath(t *testing.T) {
+	for _, near := range []bool{false, true} {
+		t.Run(fmt.Sprintf("respawn_near_death=%t", near), func(t *testing.T) {
+			writeWorldFile(t, fmt.Sprintf(`{"respawn_near_death": %t}`, near))
+			s, handles := newTestServer(t, "pilot")
+			h := handles[0]
+
+			// Strip the spawn invincibility and park an asteroid on the ship
+			const deathX, deathY = 300, 50
+			spawnAt(t, s, h, deathX, deathY, 0)
+			h.InvincibleTime = 0
+			addStillAsteroid(s, deathX, deathY, object.AsteroidSmall)
+			tick(s)
+			if h.Player != nil {
+				t.Fatal("ship survived the asteroid")
+			}
+
+			far := 0
+			for i := 0; i < 20; i++ {
+				h.hasLastDeath = true // Each respawn uses up the death location
+				h.RespawnTimeRemaining = 0
+				p := s.SpawnPlayer(h.ID)
+				if p == nil {
+					t.Fatal("SpawnPlayer spawned nothing")
+				}
+				dx := wrapDelta(p.X-deathX, float64(s.world.World.Width))
+				dy := wrapDelta(p.Y-deathY, float64(s.world.World.Height))
+				if math.Hypot(dx, dy) > config.RespawnNearRadius {
+					far++
+				}
+				s.RemovePlayer(h.ID)
+			}
+			if near && far > 0 {
+				t.Errorf("%d of 20 respawns farther than %g from the death", far, config.RespawnNearRadius)
+			}
+			if !near && far == 0 {
+				t.Error("all 20 respawns near the death, want anywhere in the world")
+			}
+		})
+	}
+}
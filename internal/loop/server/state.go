@@ -11,28 +11,59 @@ import (
 type ChatMessage struct {
 	Username string
 	Text     string
+	At       time.Time // When the server received it
+}
+
+// PlayerStats holds per-session shooting statistics for a client.
+type PlayerStats struct {
+	ShotsFired int // Projectiles fired this session
+	ShotsHit   int // Projectiles that hit an asteroid or another player
+}
+
+// Accuracy returns the percentage of fired shots that hit (0 when nothing was fired).
+func (s PlayerStats) Accuracy() float64 {
+	if s.ShotsFired == 0 {
+		return 0
+	}
+	return float64(s.ShotsHit) / float64(s.ShotsFired) * 100
+}
+
+// SessionStats summarizes a client's session for the end-of-session screen.
+type SessionStats struct {
+	PlayerStats
+	TimePlayed         float64 // Seconds spent alive across all ships
+	BestScore          int     // Highest score reached this session
+	AsteroidsDestroyed int     // Asteroids destroyed this session
+	Kills              int     // Players destroyed this session
+	Deaths             int     // Ships lost this session
+	Rank               int     // 1-based rank by best score among connected players
+	Players            int     // Connected players the rank is out of
 }
 
 // TopScoreEntry represents a single entry on the leaderboard.
 type TopScoreEntry struct {
 	Username string
 	Score    int
-	clientID int // Used for deterministic tie-break when scores are equal
+	Stats    PlayerStats // Shooting stats, for optional display alongside the score
+	ClientID int         // Client the score belongs to; also breaks ties deterministically
 }
 
 // WorldState holds shared game state (objects, world bounds, timing).
 // This is managed by the Server and shared across all clients via snapshots.
 type WorldState struct {
 	Objects       []object.Object
-	toSpawn       []object.Object // Objects to add after current update cycle
-	Screen        object.Screen   // Used for update context (world bounds)
-	World         object.Screen   // World dimensions (total game area)
-	Delta         time.Duration   // Frame delta time
-	AsteroidCount int             // Weighted asteroid count maintained incrementally
+	toSpawn       []object.Object     // Objects to add after current update cycle
+	Screen        object.Screen       // Used for update context (world bounds)
+	World         object.Screen       // World dimensions (total game area)
+	Delta         time.Duration       // Frame delta time
+	AsteroidCount int                 // Weighted asteroid count maintained incrementally
+	AsteroidSplit object.SplitPattern // Split pattern the asteroid weights are based on
+	Wave          int                 // Current asteroid wave (0 = the world has no waves)
 
 	// Reusable caches for collision detection (avoids allocations)
 	projectileCache []*object.Projectile
 	asteroidCache   []*object.Asteroid
+	powerUpCache    []*object.PowerUp
 
 	// Spatial grids for broad-phase collision detection (reused each frame)
 	asteroidGrid   *physics.SpatialGrid
@@ -40,20 +71,46 @@ type WorldState struct {
 }
 
 // WorldSnapshot is an immutable snapshot of the world state for rendering.
+// Objects and UserObjects are copies made under the server lock (see
+// object.CopyObjects), so clients may read them without locking; they must
+// not modify them, since other clients share the same snapshot.
 type WorldSnapshot struct {
 	Objects      []object.Object
 	UserObjects  []*object.User
 	Players      int
 	World        object.Screen
+	Bounded      bool // World edges are walls rather than wrapping around
 	Delta        time.Duration
-	TopScores    []TopScoreEntry  // Top N scores for leaderboard display
+	TopScores    []TopScoreEntry // Top N scores for leaderboard display
 	ChatMessages []ChatMessage   // Recent chat messages for all clients
+
+	// Wave is the current asteroid wave, or 0 when the world has no waves.
+	Wave int
+	// Difficulty is the asteroid population the world currently maintains
+	// relative to its target: below 1 while a sparse start ramps up, 1 once
+	// settled.
+	Difficulty float64
+}
+
+// Ship returns the snapshot copy of the client's ship, or nil if the client
+// had no ship when the snapshot was taken.
+func (s *WorldSnapshot) Ship(clientID int) *object.User {
+	for _, u := range s.UserObjects {
+		if u.OwnerID == clientID {
+			return u
+		}
+	}
+	return nil
 }
 
 // collisionGridCellSize is the cell size for the spatial hash grids.
 // Must be >= the largest collision distance (two large asteroids: 5.0 + 5.0 = 10.0).
 const collisionGridCellSize = 10.0
 
+// hugeCollisionGridCellSize replaces collisionGridCellSize when huge
+// asteroids can spawn (two huge asteroids: 8.0 + 8.0 = 16.0).
+const hugeCollisionGridCellSize = 16.0
+
 // NewWorldState creates a new initialized world state.
 func NewWorldState() *WorldState {
 	return &WorldState{
@@ -62,29 +119,31 @@ func NewWorldState() *WorldState {
 }
 
 // InitGrids creates the spatial grids for broad-phase collision detection.
+// huge reports whether huge asteroids can spawn, which need larger cells.
 // Must be called after World dimensions are set.
-func (w *WorldState) InitGrids() {
+func (w *WorldState) InitGrids(huge bool) {
 	worldW := float64(w.World.Width)
 	worldH := float64(w.World.Height)
-	w.asteroidGrid = physics.NewSpatialGrid(worldW, worldH, collisionGridCellSize)
-	w.projectileGrid = physics.NewSpatialGrid(worldW, worldH, collisionGridCellSize)
+	cell := collisionGridCellSize
+	if huge {
+		cell = hugeCollisionGridCellSize
+	}
+	w.asteroidGrid = physics.NewSpatialGrid(worldW, worldH, cell)
+	w.projectileGrid = physics.NewSpatialGrid(worldW, worldH, cell)
 }
 
-// asteroidWeight returns the weighted count for an asteroid by size.
-// Large=4 (splits into 2 medium), Medium=2 (splits into 2 small), Small=1.
-// Returns 0 for non-asteroid objects.
-func asteroidWeight(obj object.Object) int {
+// asteroidWeight returns the weighted count for an asteroid: the number of
+// small asteroids it can split into under AsteroidSplit (with the default
+// pattern huge=8, large=4, medium=2, small=1). Returns 0 for non-asteroid objects.
+// Destroyed asteroids keep their weight, so RemoveObject takes it back off.
+func (w *WorldState) asteroidWeight(obj object.Object) int {
 	a, ok := obj.(*object.Asteroid)
-	if !ok || a.IsDestroyed() {
+	if !ok {
 		return 0
 	}
 	switch a.Size {
-	case object.AsteroidLarge:
-		return 4
-	case object.AsteroidMedium:
-		return 2
-	case object.AsteroidSmall:
-		return 1
+	case object.AsteroidHuge, object.AsteroidLarge, object.AsteroidMedium, object.AsteroidSmall:
+		return w.AsteroidSplit.Weight(a.Size)
 	default:
 		return 0
 	}
@@ -93,13 +152,13 @@ func asteroidWeight(obj object.Object) int {
 // AddObject adds an object to the game world.
 func (w *WorldState) AddObject(obj object.Object) {
 	w.Objects = append(w.Objects, obj)
-	w.AsteroidCount += asteroidWeight(obj)
+	w.AsteroidCount += w.asteroidWeight(obj)
 }
 
 // RemoveObject decrements the asteroid count for a removed object.
 // Call this when removing an object that was tracked via AddObject.
 func (w *WorldState) RemoveObject(obj object.Object) {
-	w.AsteroidCount -= asteroidWeight(obj)
+	w.AsteroidCount -= w.asteroidWeight(obj)
 }
 
 // Spawn queues an object to be added after the current update cycle.
@@ -111,7 +170,7 @@ func (w *WorldState) Spawn(obj object.Object) {
 // FlushSpawned adds all queued objects to the game and clears the queue.
 func (w *WorldState) FlushSpawned() {
 	for _, obj := range w.toSpawn {
-		w.AsteroidCount += asteroidWeight(obj)
+		w.AsteroidCount += w.asteroidWeight(obj)
 	}
 	w.Objects = append(w.Objects, w.toSpawn...)
 	w.toSpawn = w.toSpawn[:0]
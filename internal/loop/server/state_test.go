@@ -0,0 +1,62 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// TestWeightedCountThroughSplits shatters a huge asteroid tier by tier and
+// checks that the world's incremental AsteroidCount always matches the
+// weights of the asteroids actually in it.
+func TestWeightedCountThroughSplits(t *testing.T) {
+	s, _ := newTestServer(t)
+	addStillAsteroid(s, 200, 200, object.AsteroidHuge)
+
+	check := func(stage string) {
+		t.Helper()
+		sum := 0
+		for size, n := range countAsteroids(s) {
+			sum += n * s.world.AsteroidSplit.Weight(size)
+		}
+		if s.world.AsteroidCount != sum {
+			t.Errorf("%s: AsteroidCount = %d, want %d", stage, s.world.AsteroidCount, sum)
+		}
+	}
+	check("huge")
+	if want := s.world.AsteroidSplit.Weight(object.AsteroidHuge); s.world.AsteroidCount != want {
+		t.Fatalf("AsteroidCount = %d for one huge asteroid, want %d", s.world.AsteroidCount, want)
+	}
+
+	for _, next := range []string{"large", "medium", "small", "none"} {
+		for _, obj := range s.world.Objects {
+			if a, ok := obj.(*object.Asteroid); ok {
+				a.MarkDestroyed()
+			}
+		}
+		tick(s) // Destroyed asteroids split and leave on their next update
+		check(next)
+		// Splitting keeps the weight until the smalls are gone
+		if next != "none" && s.world.AsteroidCount != s.world.AsteroidSplit.Weight(object.AsteroidHuge) {
+			t.Errorf("%s: AsteroidCount = %d, want %d", next, s.world.AsteroidCount, s.world.AsteroidSplit.Weight(object.AsteroidHuge))
+		}
+	}
+	if s.world.AsteroidCount != 0 {
+		t.Errorf("AsteroidCount = %d with every asteroid destroyed, want 0", s.world.AsteroidCount)
+	}
+	if n := countAsteroids(s); len(n) != 0 {
+		t.Errorf("asteroids left = %v, want none", n)
+	}
+}
+
+func TestAsteroidScore(t *testing.T) {
+	// Bigger asteroids are easier to hit and worth less, except the huge
+	// tier, which is the rarest and most valuable.
+	large := asteroidScore(object.AsteroidLarge)
+	medium := asteroidScore(object.AsteroidMedium)
+	small := asteroidScore(object.AsteroidSmall)
+	huge := asteroidScore(object.AsteroidHuge)
+	if !(large < medium && medium < small && small < huge) {
+		t.Errorf("scores large %d, medium %d, small %d, huge %d: want increasing, huge highest", large, medium, small, huge)
+	}
+}
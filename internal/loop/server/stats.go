@@ -0,0 +1,66 @@
+package server
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// rateWindow is how often the per-second rates in ServerStats are sampled.
+const rateWindow = time.Second
+
+// ServerStats is a point-in-time summary of server load for operators.
+type ServerStats struct {
+	Players           int   // Connected clients
+	RenderBytes       int64 // Terminal output bytes rendered for all clients since start
+	RenderBytesPerSec int64 // Render bytes per second, all clients, over the last second
+	Churn             int64 // Client registrations plus unregistrations since start
+	ChurnPerSec       int64 // Registrations plus unregistrations per second over the last second
+}
+
+// rateCounter is a running total, safe to add to from any goroutine, plus
+// its per-second rate as of the last sample.
+type rateCounter struct {
+	total atomic.Int64
+	rate  atomic.Int64
+	last  int64 // total at the previous sample (server loop only)
+}
+
+// sample stores the rate since the previous sample, elapsed ago.
+func (r *rateCounter) sample(elapsed time.Duration) {
+	total := r.total.Load()
+	r.rate.Store(int64(float64(total-r.last) / elapsed.Seconds()))
+	r.last = total
+}
+
+// AddRenderBytes records n bytes of terminal output sent to a client.
+// Safe to call from any goroutine; clients call it once per frame.
+func (s *Server) AddRenderBytes(n int) {
+	s.renderBytes.total.Add(int64(n))
+}
+
+// sampleRates updates the per-second rates once per rateWindow.
+// Called from the server loop only.
+func (s *Server) sampleRates(now time.Time) {
+	if s.sampledAt.IsZero() {
+		s.sampledAt = now
+		return
+	}
+	elapsed := now.Sub(s.sampledAt)
+	if elapsed < rateWindow {
+		return
+	}
+	s.renderBytes.sample(elapsed)
+	s.churn.sample(elapsed)
+	s.sampledAt = now
+}
+
+// Stats returns current load figures (thread-safe).
+func (s *Server) Stats() ServerStats {
+	return ServerStats{
+		Players:           s.GetSnapshot().Players,
+		RenderBytes:       s.renderBytes.total.Load(),
+		RenderBytesPerSec: s.renderBytes.rate.Load(),
+		Churn:             s.churn.total.Load(),
+		ChurnPerSec:       s.churn.rate.Load(),
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"github.com/tomz197/asteroids/internal/loop/config"
+	"github.com/tomz197/asteroids/internal/object"
+)
+
+// updateWavesLocked runs the wave cycle of a world played in waves: once
+// every asteroid of the current wave is destroyed, it waits config.WaveBreak
+// and then starts the next one. The first wave starts on the first tick.
+func (s *Server) updateWavesLocked(dt float64) {
+	if s.world.AsteroidCount > 0 {
+		return
+	}
+	if s.world.Wave > 0 && s.waveBreak < config.WaveBreak.Seconds() {
+		s.waveBreak += dt
+		return
+	}
+	s.waveBreak = 0
+	s.startWaveLocked(s.world.Wave + 1)
+}
+
+// startWaveLocked spawns the large asteroids of the given wave, moving at
+// the wave's speed, and tells every client that the wave began.
+func (s *Server) startWaveLocked(wave int) {
+	s.world.Wave = wave
+	budget, speed := waveBudget(wave), waveSpeedScale(wave)
+	value := max(s.world.AsteroidSplit.Weight(object.AsteroidLarge), 1)
+	count := max(budget/value, 1)
+	for i := 0; i < count; i++ {
+		a := object.NewAsteroidRandom(s.world.Screen, object.AsteroidLarge, object.SpawnProtectionTime)
+		a.VX *= speed
+		a.VY *= speed
+		s.world.AddObject(a)
+	}
+
+	for _, handle := range s.clients {
+		select {
+		case handle.EventsCh <- ClientEvent{Type: EventWave, Wave: wave}:
+		default:
+		}
+	}
+}
+
+// waveBudget returns the weighted asteroid count of a wave: WaveBaseAsteroids,
+// growing by WaveAsteroidStep per wave, capped at the largest world target.
+func waveBudget(wave int) int {
+	return min(config.WaveBaseAsteroids+(wave-1)*config.WaveAsteroidStep, maxAsteroidTarget)
+}
+
+// waveSpeedScale returns the speed multiplier of a wave's asteroids.
+func waveSpeedScale(wave int) float64 {
+	return min(1+float64(wave-1)*config.WaveSpeedStep, config.WaveMaxSpeedScale)
+}
@@ -1,6 +1,7 @@
 package object
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 
@@ -14,6 +15,7 @@ const (
 	AsteroidSmall  AsteroidSize = 1
 	AsteroidMedium AsteroidSize = 2
 	AsteroidLarge  AsteroidSize = 3
+	AsteroidHuge   AsteroidSize = 4 // Optional extra tier (see AsteroidSpawnerConfig.HugeChance); splits into larges
 )
 
 // Size properties for each asteroid size.
@@ -21,28 +23,98 @@ var asteroidRadii = map[AsteroidSize]float64{
 	AsteroidSmall:  1.5,
 	AsteroidMedium: 3.0,
 	AsteroidLarge:  5.0,
+	AsteroidHuge:   8.0,
 }
 
 var asteroidSpeeds = map[AsteroidSize]float64{
 	AsteroidSmall:  15.0,
 	AsteroidMedium: 10.0,
 	AsteroidLarge:  6.0,
+	AsteroidHuge:   4.0,
+}
+
+// SplitPattern controls how a destroyed asteroid breaks up into the next
+// smaller size.
+type SplitPattern struct {
+	Count  int     // Fragments per split (1-8)
+	Spread float64 // Fan of fragment headings in radians, centered on the parent's heading; 0 = random directions
+}
+
+// maxSplitCount caps SplitPattern.Count, since weights grow as Count^2 per large asteroid.
+const maxSplitCount = 8
+
+// Validate reports whether the pattern is usable.
+func (p SplitPattern) Validate() error {
+	if p.Count < 1 || p.Count > maxSplitCount {
+		return fmt.Errorf("asteroid split count must be 1-%d (got %d)", maxSplitCount, p.Count)
+	}
+	if p.Spread < 0 || p.Spread > 2*math.Pi {
+		return fmt.Errorf("asteroid split spread must be 0-2π radians (got %g)", p.Spread)
+	}
+	return nil
+}
+
+// Weight returns how many small asteroids one asteroid of the given size
+// eventually breaks into (Count^(size-1)). Used to keep the weighted asteroid
+// population consistent with the split pattern.
+func (p SplitPattern) Weight(size AsteroidSize) int {
+	w := 1
+	for s := AsteroidSmall; s < size; s++ {
+		w *= p.Count
+	}
+	return w
+}
+
+// heading returns the direction of fragment i of n for a parent moving at
+// heading: evenly fanned across Spread (a full-circle spread spaces them
+// evenly all around), or random when Spread is 0.
+func (p SplitPattern) heading(i, n int, heading float64) float64 {
+	switch {
+	case p.Spread == 0:
+		return rand.Float64() * 2 * math.Pi
+	case n == 1:
+		return heading
+	case p.Spread >= 2*math.Pi:
+		return heading + float64(i)*2*math.Pi/float64(n)
+	default:
+		return heading + p.Spread*(float64(i)/float64(n-1)-0.5)
+	}
+}
+
+// asteroidShape describes the silhouette of one asteroid size.
+type asteroidShape struct {
+	minVerts, maxVerts int     // Polygon vertex count range
+	jag                float64 // Vertex distance varies over radius*(1±jag/2)
+}
+
+// asteroidShapes gives each size a distinct silhouette, so size (and so
+// threat and score) reads at a glance: large rocks are jagged with many
+// vertices, small ones rounder with few. Purely visual; the hitbox is
+// still Radius. Solid asteroids (DrawContext.FilledAsteroids) fill the same
+// polygon, so the silhouettes carry over, though a jagged outline reads
+// better than its fill at small sizes. Colors are independent of shape.
+var asteroidShapes = map[AsteroidSize]asteroidShape{
+	AsteroidSmall:  {minVerts: 6, maxVerts: 7, jag: 0.2},
+	AsteroidMedium: {minVerts: 8, maxVerts: 10, jag: 0.4},
+	AsteroidLarge:  {minVerts: 11, maxVerts: 14, jag: 0.7},
+	AsteroidHuge:   {minVerts: 14, maxVerts: 16, jag: 0.7},
 }
 
 // maxAsteroidVertices is the maximum number of vertices an asteroid polygon can have.
-// Asteroids generate 8-12 vertices, so 12 covers the upper bound.
-const maxAsteroidVertices = 12
+// Huge asteroids generate up to 16 vertices (see asteroidShapes).
+const maxAsteroidVertices = 16
 
 // Asteroid is a destructible space rock.
 type Asteroid struct {
-	X, Y            float64      // Position (center)
-	VX, VY          float64      // Velocity
-	Angle           float64      // Current rotation angle
-	RotationSpeed   float64      // Rotation speed (radians/sec)
-	Size            AsteroidSize // Size category
-	Radius          float64      // Collision/draw radius
-	Destroyed       bool         // Mark for removal and splitting
-	SpawnProtection float64      // Seconds of invulnerability remaining after spawn
+	X, Y            float64         // Position (center)
+	VX, VY          float64         // Velocity
+	Angle           float64         // Current rotation angle
+	RotationSpeed   float64         // Rotation speed (radians/sec)
+	Size            AsteroidSize    // Size category
+	Radius          float64         // Collision/draw radius
+	Destroyed       bool            // Mark for removal and splitting
+	SpawnProtection float64         // Seconds of invulnerability remaining after spawn
+	Hitters         [maxHitters]Hit // Recent hits on this asteroid or the ones it split from (for assists)
 
 	// Fixed-size vertex arrays avoid heap allocation for each asteroid.
 	// NumVertices holds how many entries are in use.
@@ -68,14 +140,16 @@ func NewAsteroid(x, y float64, size AsteroidSize, angle float64) *Asteroid {
 	// Random rotation speed (-1 to 1 radians/sec)
 	rotSpeed := (rand.Float64() - 0.5) * 2.0
 
-	// Generate irregular polygon vertices (8-12 vertices) and pre-compute
-	// un-rotated vertex offsets so drawAt only needs one sin/cos pair per frame.
-	numVerts := 8 + rand.Intn(5)
+	// Generate an irregular polygon in the size's style (see asteroidShapes)
+	// and pre-compute un-rotated vertex offsets so drawAt only needs one
+	// sin/cos pair per frame.
+	shape := asteroidShapes[size]
+	numVerts := shape.minVerts + rand.Intn(shape.maxVerts-shape.minVerts+1)
 	var vertices [maxAsteroidVertices]float64
 	var baseVX, baseVY [maxAsteroidVertices]float64
 	angleStep := 2 * math.Pi / float64(numVerts)
 	for i := 0; i < numVerts; i++ {
-		dist := radius * (0.7 + rand.Float64()*0.6)
+		dist := radius * (1 - shape.jag/2 + rand.Float64()*shape.jag)
 		vertices[i] = dist
 		a := float64(i) * angleStep
 		baseVX[i] = math.Cos(a) * dist
@@ -152,21 +226,51 @@ func (a *Asteroid) IsProtected() bool {
 	return a.SpawnProtection > 0
 }
 
+// maxHitters is how many recent hitters an asteroid remembers.
+const maxHitters = 4
+
+// Hit records a player's hit on an asteroid, for assist scoring.
+type Hit struct {
+	OwnerID   int     // Client ID of the hitting projectile's owner
+	Remaining float64 // Seconds the hit still counts toward an assist (0 = empty slot)
+}
+
+// RecordHit remembers that ownerID hit the asteroid, counting toward an
+// assist for window seconds. A repeat hitter's window is refreshed; otherwise
+// the hit with the least time left is replaced. Fragments inherit the list
+// when the asteroid splits, so hitting a large asteroid earns an assist on
+// the pieces someone else finishes off.
+func (a *Asteroid) RecordHit(ownerID int, window float64) {
+	slot := 0
+	for i, h := range a.Hitters {
+		if h.Remaining > 0 && h.OwnerID == ownerID {
+			slot = i
+			break
+		}
+		if h.Remaining < a.Hitters[slot].Remaining {
+			slot = i
+		}
+	}
+	a.Hitters[slot] = Hit{OwnerID: ownerID, Remaining: window}
+}
+
 // Update moves the asteroid and handles rotation.
 func (a *Asteroid) Update(ctx UpdateContext) (bool, error) {
 	if a.Destroyed {
-		// Spawn explosion particles
-		particleCount := int(a.Size) * 4 // More particles for larger asteroids
-		SpawnExplosion(a.X, a.Y, particleCount, 20.0, 0.5, ctx.Spawner)
+		// Spawn explosion particles (more for larger asteroids)
+		burst := ctx.Explosions.Asteroid
+		burst.Count *= int(a.Size)
+		burst.Spawn(a.X, a.Y, ctx.Spawner)
 
 		// Spawn smaller asteroids if not the smallest size
 		if a.Size > AsteroidSmall && ctx.Spawner != nil {
-			// Spawn 2 smaller asteroids
 			newSize := a.Size - 1
-			for i := 0; i < 2; i++ {
-				// Random direction for fragments
-				angle := rand.Float64() * 2 * math.Pi
+			split := ctx.AsteroidSplit
+			heading := math.Atan2(a.VY, a.VX)
+			for i := 0; i < split.Count; i++ {
+				angle := math.Mod(split.heading(i, split.Count, heading)+2*math.Pi, 2*math.Pi)
 				child := NewAsteroid(a.X, a.Y, newSize, angle)
+				child.Hitters = a.Hitters
 				ctx.Spawner.Spawn(child)
 			}
 		}
@@ -175,6 +279,11 @@ func (a *Asteroid) Update(ctx UpdateContext) (bool, error) {
 
 	dt := ctx.Delta.Seconds()
 
+	// Age recent hits
+	for i := range a.Hitters {
+		a.Hitters[i].Remaining = max(a.Hitters[i].Remaining-dt, 0)
+	}
+
 	// Decrement spawn protection
 	if a.SpawnProtection > 0 {
 		a.SpawnProtection -= dt
@@ -186,25 +295,32 @@ func (a *Asteroid) Update(ctx UpdateContext) (bool, error) {
 	// Rotate
 	a.Angle += a.RotationSpeed * dt
 
+	// Planet mode: pull toward the world center
+	ctx.Pull(a.X, a.Y, &a.VX, &a.VY, dt)
+
 	// Move
 	a.X += a.VX * dt
 	a.Y += a.VY * dt
 
-	// Screen wrapping
-	ctx.Screen.WrapPosition(&a.X, &a.Y)
+	// Wrap around (or bounce off) world edges
+	ctx.Confine(&a.X, &a.Y, &a.VX, &a.VY)
 
 	return false, nil
 }
 
 // Draw renders the asteroid as an irregular polygon.
 func (a *Asteroid) Draw(ctx DrawContext) error {
-	// Blink when protected (skip drawing in "off" phase)
-	if !ShouldRenderBlink(a.SpawnProtection, 5.0) {
+	// Protected asteroids are tinted when a color is set, otherwise they
+	// blink (skip drawing in the "off" phase)
+	if a.IsProtected() && ctx.ProtectedColor != "" {
+		ctx.Canvas.SetAccent(ctx.ProtectedColor)
+		defer ctx.Canvas.SetAccent("")
+	} else if !ShouldRenderBlink(a.SpawnProtection, 5.0) {
 		return nil
 	}
 
 	// Get screen positions (handles world wrapping)
-	positions := WorldToScreen(a.X, a.Y, ctx.Camera, ctx.View, ctx.World)
+	positions := ctx.ToScreen(a.X, a.Y)
 
 	for i := 0; i < positions.Count; i++ {
 		pos := positions.Positions[i]
@@ -233,7 +349,7 @@ func (a *Asteroid) drawAt(ctx DrawContext, screenX, screenY float64) {
 		}
 	}
 
-	ctx.Canvas.DrawPolygon(points, false)
+	ctx.Canvas.DrawPolygon(points, ctx.FilledAsteroids)
 }
 
 // MarkDestroyed marks the asteroid for removal (implements Destructible).
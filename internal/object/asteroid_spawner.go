@@ -1,18 +1,47 @@
 package object
 
+import "math/rand"
+
+// AsteroidSpawnerConfig describes the population an AsteroidSpawner keeps.
+// All counts are weighted (see SplitPattern.Weight).
+type AsteroidSpawnerConfig struct {
+	Initial int     // Population seeded on the first update
+	Target  int     // Population maintained once ramped
+	Ramp    float64 // Units per second the maintained level moves from Initial to Target (<= 0: jump)
+	Refill  float64 // Units per second spawned at most to replace destroyed asteroids (<= 0: unlimited)
+
+	// HugeChance is the chance that a spawned asteroid is AsteroidHuge
+	// rather than AsteroidLarge. 0 keeps the classic three tiers.
+	HugeChance float64
+}
+
 // AsteroidSpawner keeps the asteroid population at a target level.
+// It seeds an initial population on its first update, then moves the level
+// it maintains from there toward the target at a fixed rate, so a world can
+// start sparse and fill up, or start dense and settle lower.
+//
+// Replacements are paced by a refill budget, so a mass clear on a busy server
+// is backfilled steadily rather than all at once, and a new asteroid appears
+// as soon as there is room for one instead of waiting for a large deficit.
 type AsteroidSpawner struct {
-	target int
+	cfg    AsteroidSpawnerConfig
+	level  float64      // Weighted population currently maintained
+	credit float64      // Refill budget available, in weighted units
+	seeded bool         // Whether the initial population has been spawned
+	next   AsteroidSize // Size of the next asteroid to spawn; 0 until picked
 }
 
-// NewAsteroidSpawner creates a spawner with a target asteroid count.
-func NewAsteroidSpawner(target int) *AsteroidSpawner {
-	if target < 0 {
-		target = 0
-	}
-	return &AsteroidSpawner{
-		target: target,
-	}
+// NewAsteroidSpawner creates a spawner for cfg. Negative counts are treated as 0.
+func NewAsteroidSpawner(cfg AsteroidSpawnerConfig) *AsteroidSpawner {
+	cfg.Initial = max(cfg.Initial, 0)
+	cfg.Target = max(cfg.Target, 0)
+	return &AsteroidSpawner{cfg: cfg}
+}
+
+// Level returns the weighted population currently maintained: Initial at
+// first, moving toward Target at the ramp rate.
+func (s *AsteroidSpawner) Level() float64 {
+	return s.level
 }
 
 // SpawnProtectionTime is how long new asteroids are invulnerable.
@@ -20,29 +49,88 @@ const SpawnProtectionTime = 3.0
 
 // Update spawns asteroids at random positions when the count drops.
 func (s *AsteroidSpawner) Update(ctx UpdateContext) (bool, error) {
-	if s.target == 0 {
-		return false, nil
+	// Each asteroid counts as the small asteroids it can split into
+	// (4 for a large with the default pattern: 2 medium -> 4 small).
+	maxValue := ctx.AsteroidSplit.Weight(AsteroidLarge)
+	if s.cfg.HugeChance > 0 {
+		maxValue = ctx.AsteroidSplit.Weight(AsteroidHuge)
 	}
+	dt := ctx.Delta.Seconds()
 
-	// Use the incrementally maintained asteroid count from the server.
-	count := ctx.AsteroidCount
-	if count >= s.target {
-		return false, nil
+	seeding := !s.seeded
+	if seeding {
+		s.seeded = true
+		s.level = float64(s.cfg.Initial)
+	} else {
+		s.level = s.rampLevel(dt)
+		s.refillCredit(dt, maxValue)
 	}
 
-	// Spawn large asteroids in batches when significantly below target.
-	// Each large asteroid counts as 4 (can split into 2 medium -> 4 small).
-	const largeAsteroidValue = 4
-	const batchThreshold = 12
+	// Use the incrementally maintained asteroid count from the server.
+	goal := int(s.level)
+	count := ctx.AsteroidCount
 
-	for s.target-count >= batchThreshold {
-		asteroid := NewAsteroidRandom(ctx.Screen, AsteroidLarge, SpawnProtectionTime)
+	// Spawn whole asteroids while the next one still fits under the goal;
+	// the seed is spawned at once, replacements only as the refill budget
+	// allows.
+	for {
+		if s.next == 0 {
+			s.next = s.pickSize()
+		}
+		value := ctx.AsteroidSplit.Weight(s.next)
+		if goal-count < value {
+			if s.next == AsteroidHuge && goal-count >= ctx.AsteroidSplit.Weight(AsteroidLarge) {
+				s.next = AsteroidLarge // No room for a huge one; don't stall on it
+				continue
+			}
+			break
+		}
+		if !seeding && s.cfg.Refill > 0 {
+			if s.credit < float64(value) {
+				break
+			}
+			s.credit -= float64(value)
+		}
+		asteroid := NewAsteroidRandom(ctx.Screen, s.next, SpawnProtectionTime)
 		ctx.Spawner.Spawn(asteroid)
-		count += largeAsteroidValue
+		count += value
+		s.next = 0
 	}
 	return false, nil
 }
 
+// pickSize returns the size of the next spawned asteroid: usually large,
+// huge with probability HugeChance.
+func (s *AsteroidSpawner) pickSize() AsteroidSize {
+	if s.cfg.HugeChance > 0 && rand.Float64() < s.cfg.HugeChance {
+		return AsteroidHuge
+	}
+	return AsteroidLarge
+}
+
+// refillCredit adds dt seconds of refill budget, saving up at most one
+// second's worth (and always enough for one of the largest asteroids).
+func (s *AsteroidSpawner) refillCredit(dt float64, maxValue int) {
+	if s.cfg.Refill <= 0 {
+		return
+	}
+	limit := max(s.cfg.Refill, float64(maxValue))
+	s.credit = min(s.credit+s.cfg.Refill*dt, limit)
+}
+
+// rampLevel returns the maintained level moved dt seconds toward target.
+func (s *AsteroidSpawner) rampLevel(dt float64) float64 {
+	target := float64(s.cfg.Target)
+	if s.cfg.Ramp <= 0 {
+		return target
+	}
+	step := s.cfg.Ramp * dt
+	if s.level < target {
+		return min(s.level+step, target)
+	}
+	return max(s.level-step, target)
+}
+
 // Draw is a no-op; spawner is not visible.
 func (s *AsteroidSpawner) Draw(_ DrawContext) error {
 	return nil
@@ -0,0 +1,146 @@
+package object
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitPatternWeight(t *testing.T) {
+	tests := []struct {
+		count                      int
+		small, medium, large, huge int
+	}{
+		{1, 1, 1, 1, 1},
+		{2, 1, 2, 4, 8},
+		{3, 1, 3, 9, 27},
+	}
+	for _, tt := range tests {
+		p := SplitPattern{Count: tt.count}
+		got := [4]int{p.Weight(AsteroidSmall), p.Weight(AsteroidMedium), p.Weight(AsteroidLarge), p.Weight(AsteroidHuge)}
+		want := [4]int{tt.small, tt.medium, tt.large, tt.huge}
+		if got != want {
+			t.Errorf("Count %d: weights small..huge = %v, want %v", tt.count, got, want)
+		}
+	}
+}
+
+// spawnRecorder is a Spawner that keeps what it is given.
+type spawnRecorder []Object
+
+func (r *spawnRecorder) Spawn(obj Object) { *r = append(*r, obj) }
+
+func TestAsteroidSpawnerWeightedSeed(t *testing.T) {
+	tests := []struct {
+		name       string
+		hugeChance float64
+		wantSizes  map[AsteroidSize]int
+	}{
+		{"classic", 0, map[AsteroidSize]int{AsteroidLarge: 5}},
+		// Two huge ones (16) leave room for a large (4), not a third huge (8)
+		{"huge", 1, map[AsteroidSize]int{AsteroidHuge: 2, AsteroidLarge: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			split := SplitPattern{Count: 2}
+			s := NewAsteroidSpawner(AsteroidSpawnerConfig{Initial: 20, Target: 20, HugeChance: tt.hugeChance})
+			var spawned spawnRecorder
+			s.Update(UpdateContext{
+				Delta:         time.Second / 60,
+				Screen:        Screen{Width: 400, Height: 400},
+				Spawner:       &spawned,
+				AsteroidSplit: split,
+			})
+
+			sizes := make(map[AsteroidSize]int)
+			weight := 0
+			for _, obj := range spawned {
+				a := obj.(*Asteroid)
+				sizes[a.Size]++
+				weight += split.Weight(a.Size)
+			}
+			if weight != 20 {
+				t.Errorf("seeded weight = %d, want 20", weight)
+			}
+			if len(sizes) != len(tt.wantSizes) {
+				t.Errorf("seeded sizes = %v, want %v", sizes, tt.wantSizes)
+			}
+			for size, n := range tt.wantSizes {
+				if sizes[size] != n {
+					t.Errorf("seeded sizes = %v, want %v", sizes, tt.wantSizes)
+				}
+			}
+		})
+	}
+}
+
+func TestAsteroidSpawnerDecisions(t *testing.T) {
+	// Each step runs one update with the given delta and population, and
+	// expects that many large asteroids (weight 4) to be spawned.
+	type step struct {
+		dt    time.Duration
+		count int
+		want  int
+	}
+	tests := []struct {
+		name  string
+		cfg   AsteroidSpawnerConfig
+		steps []step
+	}{
+		{"seed fills to initial", AsteroidSpawnerConfig{Initial: 20, Target: 20}, []step{
+			{time.Second / 60, 0, 5},
+		}},
+		{"seed counts existing asteroids", AsteroidSpawnerConfig{Initial: 20, Target: 20}, []step{
+			{time.Second / 60, 20, 0},
+		}},
+		{"unlimited refill replaces whole asteroids only", AsteroidSpawnerConfig{Initial: 20, Target: 20}, []step{
+			{time.Second / 60, 20, 0},
+			{time.Second / 60, 17, 0}, // Deficit 3 leaves no room for a large
+			{time.Second / 60, 16, 1},
+			{time.Second / 60, 0, 5},
+		}},
+		{"refill is paced by its budget", AsteroidSpawnerConfig{Initial: 40, Target: 40, Refill: 4}, []step{
+			{time.Second / 60, 40, 0},
+			{time.Second / 2, 0, 0}, // 2 units saved, a large needs 4
+			{time.Second / 2, 0, 1},
+			{time.Second / 2, 4, 0},
+		}},
+		{"refill budget saves up one second", AsteroidSpawnerConfig{Initial: 40, Target: 40, Refill: 8}, []step{
+			{time.Second / 60, 40, 0},
+			{10 * time.Second, 40, 0}, // At the goal: budget capped at 8
+			{time.Second / 60, 0, 2},
+		}},
+		{"ramp up raises the goal gradually", AsteroidSpawnerConfig{Initial: 0, Target: 20, Ramp: 10}, []step{
+			{time.Second / 60, 0, 0},
+			{300 * time.Millisecond, 0, 0}, // Level 3
+			{100 * time.Millisecond, 0, 1}, // Level 4
+			{10 * time.Second, 4, 4},       // Level 20
+		}},
+		{"ramp down spawns nothing", AsteroidSpawnerConfig{Initial: 20, Target: 0, Ramp: 10}, []step{
+			{time.Second / 60, 0, 5},
+			{time.Second, 12, 0}, // Level 10, population above it
+			{time.Second, 4, 0},  // Level 0
+		}},
+		{"no ramp jumps to target", AsteroidSpawnerConfig{Initial: 0, Target: 20}, []step{
+			{time.Second / 60, 0, 0},
+			{time.Second / 60, 0, 5},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAsteroidSpawner(tt.cfg)
+			for i, st := range tt.steps {
+				var spawned spawnRecorder
+				s.Update(UpdateContext{
+					Delta:         st.dt,
+					Screen:        Screen{Width: 400, Height: 400},
+					Spawner:       &spawned,
+					AsteroidSplit: SplitPattern{Count: 2},
+					AsteroidCount: st.count,
+				})
+				if len(spawned) != st.want {
+					t.Errorf("step %d (count %d, level %.1f): spawned %d, want %d", i, st.count, s.Level(), len(spawned), st.want)
+				}
+			}
+		})
+	}
+}
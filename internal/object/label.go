@@ -0,0 +1,38 @@
+package object
+
+import "unicode/utf8"
+
+// Label is a static text marker anchored to a world position (e.g. "SPAWN").
+// It never moves, never expires and does not collide with anything.
+type Label struct {
+	X, Y float64 // World position of the text center
+	Text string
+}
+
+// NewLabel creates a label centered on the given world position.
+func NewLabel(x, y float64, text string) *Label {
+	return &Label{X: x, Y: y, Text: text}
+}
+
+// Update is a no-op; labels are permanent.
+func (l *Label) Update(ctx UpdateContext) (bool, error) {
+	return false, nil
+}
+
+// Draw queues the label text at every on-screen wrap position.
+// The canvas clips text that is partially off-screen and cleans it up
+// on the next frame.
+func (l *Label) Draw(ctx DrawContext) error {
+	if l.Text == "" {
+		return nil
+	}
+
+	halfWidth := utf8.RuneCountInString(l.Text) / 2
+	positions := ctx.ToScreen(l.X, l.Y)
+	for i := 0; i < positions.Count; i++ {
+		pos := positions.Positions[i]
+		col, row := ctx.Canvas.LogicalToTerminal(pos.X, pos.Y)
+		ctx.Canvas.DrawText(col-halfWidth, row, l.Text)
+	}
+	return nil
+}
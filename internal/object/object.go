@@ -7,6 +7,7 @@ import (
 
 	"github.com/tomz197/asteroids/internal/draw"
 	"github.com/tomz197/asteroids/internal/input"
+	"github.com/tomz197/asteroids/internal/physics"
 )
 
 // Spawner allows objects to spawn new objects during update.
@@ -24,7 +25,40 @@ type UpdateContext struct {
 	Screen        Screen
 	Spawner       Spawner
 	Objects       []Object
-	AsteroidCount int // Weighted asteroid count (large=4, medium=2, small=1)
+	AsteroidCount int               // Weighted asteroid count (see SplitPattern.Weight)
+	AsteroidSplit SplitPattern      // How destroyed asteroids break up
+	DragModel     physics.DragModel // How velocity decays under drag
+	TurnRamp      TurnRamp          // Turning speed by key-hold duration
+	Explosions    ExplosionConfig   // Particle bursts for explosions and muzzle flashes
+	Bounded       bool              // World edges are walls: objects bounce off them instead of wrapping
+	Gravity       float64           // Pull toward the world center in units/s² (0 = free drift)
+	FireMode      FireMode          // How the player being updated shoots (players only)
+	Hyperspace    HyperspaceConfig  // Hyperspace jump cooldown and risk (players only)
+}
+
+// Pull accelerates a velocity toward the world center by ctx.Gravity for dt
+// seconds. The pull is uniform: its strength doesn't depend on distance.
+func (ctx UpdateContext) Pull(x, y float64, vx, vy *float64, dt float64) {
+	if ctx.Gravity == 0 {
+		return
+	}
+	dx, dy := float64(ctx.Screen.Width)/2-x, float64(ctx.Screen.Height)/2-y
+	dist := math.Hypot(dx, dy)
+	if dist < 1 {
+		return // At the center; no meaningful direction
+	}
+	*vx += dx / dist * ctx.Gravity * dt
+	*vy += dy / dist * ctx.Gravity * dt
+}
+
+// Confine keeps a moving object inside the world: it wraps the position
+// around the edges, or in a Bounded world reflects it off them.
+func (ctx UpdateContext) Confine(x, y, vx, vy *float64) {
+	if ctx.Bounded {
+		ctx.Screen.ReflectPosition(x, y, vx, vy)
+		return
+	}
+	ctx.Screen.WrapPosition(x, y)
 }
 
 // Camera represents the viewport position in world space.
@@ -34,11 +68,27 @@ type Camera struct {
 
 // DrawContext provides drawing resources for objects.
 type DrawContext struct {
-	Canvas *draw.Canvas // High-resolution canvas (2x vertical)
-	Writer io.Writer    // Direct terminal output (for text/particles)
-	Camera Camera       // Camera position for viewport offset
-	View   Screen       // Viewport dimensions (what the camera sees)
-	World  Screen       // World dimensions (total game area)
+	Canvas  *draw.Canvas // High-resolution canvas (2x vertical)
+	Writer  io.Writer    // Direct terminal output, written before the canvas; use Canvas.DrawText for text on top
+	Camera  Camera       // Camera position for viewport offset
+	View    Screen       // Viewport dimensions (what the camera sees)
+	World   Screen       // World dimensions (total game area)
+	Bounded bool         // World edges are walls, so objects are drawn only where they are, never wrapped
+
+	// FilledAsteroids draws asteroids as solid polygons instead of outlines.
+	// More visible, but the scanline fill touches every interior pixel, so
+	// it costs noticeably more per frame with many large asteroids on screen.
+	FilledAsteroids bool
+
+	ParticleStyle   ParticleStyle   // How explosion and thrust particles are drawn
+	ProjectileStyle ProjectileStyle // How projectiles are drawn
+	ProtectedColor  string          // SGR color for spawn-protected asteroids; "" makes them blink instead
+	ExpiringColor   string          // SGR color for projectiles about to expire; "" makes them blink instead
+	ParticleHeat    bool            // Tint particles from yellow to red as they fade (see Canvas.SetColored)
+
+	// ShipColors are SGR colors for ships, indexed by User.Color modulo the
+	// length. Empty draws ships in the canvas's current accent.
+	ShipColors []string
 }
 
 // Screen represents terminal dimensions.
@@ -49,6 +99,30 @@ type Screen struct {
 	CenterY int
 }
 
+// ReflectPosition mirrors x and y back inside screen boundaries, pointing the
+// matching velocity component away from the edge that was crossed. Speed is
+// unchanged.
+func (s Screen) ReflectPosition(x, y, vx, vy *float64) {
+	reflectAxis(x, vx, float64(s.Width))
+	reflectAxis(y, vy, float64(s.Height))
+}
+
+// reflectAxis reflects a single coordinate off the walls at 0 and size.
+func reflectAxis(p, v *float64, size float64) {
+	if size <= 0 {
+		return
+	}
+	if *p < 0 {
+		*p = -*p
+		*v = math.Abs(*v)
+	} else if *p > size {
+		*p = 2*size - *p
+		*v = -math.Abs(*v)
+	}
+	// A step longer than the world can overshoot the opposite wall
+	*p = math.Max(0, math.Min(*p, size))
+}
+
 // WrapPosition wraps x and y coordinates around screen boundaries (Asteroids-style).
 func (s Screen) WrapPosition(x, y *float64) {
 	w := float64(s.Width)
@@ -94,14 +168,12 @@ func WorldToScreen(worldX, worldY float64, cam Camera, view, world Screen) Scree
 	screenY := worldY - camTop
 
 	// Check all possible wrap positions (original + wrapped copies)
-	margin := 10.0
 	for dx := -1; dx <= 1; dx++ {
 		for dy := -1; dy <= 1; dy++ {
 			sx := screenX + float64(dx)*worldW
 			sy := screenY + float64(dy)*worldH
 
-			// Check if this position is within the view (with some margin for large objects)
-			if sx >= -margin && sx <= viewW+margin && sy >= -margin && sy <= viewH+margin {
+			if inViewMargin(sx, sy, view) {
 				if result.Count < 4 {
 					result.Positions[result.Count] = draw.Point{X: sx, Y: sy}
 					result.Count++
@@ -113,6 +185,30 @@ func WorldToScreen(worldX, worldY float64, cam Camera, view, world Screen) Scree
 	return result
 }
 
+// ToScreen returns the screen positions to draw an object at world position
+// (x, y): the wrapped copies from WorldToScreen, or in a Bounded world only
+// the position itself, if it is in view.
+func (ctx DrawContext) ToScreen(x, y float64) ScreenPositions {
+	if !ctx.Bounded {
+		return WorldToScreen(x, y, ctx.Camera, ctx.View, ctx.World)
+	}
+	var result ScreenPositions
+	sx := x - (ctx.Camera.X - float64(ctx.View.Width)/2)
+	sy := y - (ctx.Camera.Y - float64(ctx.View.Height)/2)
+	if inViewMargin(sx, sy, ctx.View) {
+		result.Positions[0] = draw.Point{X: sx, Y: sy}
+		result.Count = 1
+	}
+	return result
+}
+
+// inViewMargin reports whether screen position (sx, sy) is within the view,
+// with some margin for large objects.
+func inViewMargin(sx, sy float64, view Screen) bool {
+	const margin = 10.0
+	return sx >= -margin && sx <= float64(view.Width)+margin && sy >= -margin && sy <= float64(view.Height)+margin
+}
+
 // Object is a drawable and updatable game entity.
 type Object interface {
 	// Update updates the object state. Returns true if the object should be removed.
@@ -0,0 +1,118 @@
+package object
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// edgeCases start an object just inside each world edge, heading out.
+var edgeCases = []struct {
+	name           string
+	x, y           float64
+	vx, vy         float64
+	flipX, flipY   bool
+	wrapX, wrapY   float64 // Position after one step in a wrapping world
+	boundX, boundY float64 // Position after one step in a bounded world
+}{
+	{"left", 1, 50, -20, 5, true, false, 99, 50.5, 1, 50.5},
+	{"right", 99, 50, 20, 5, true, false, 1, 50.5, 99, 50.5},
+	{"top", 50, 1, 5, -20, false, true, 50.5, 99, 50.5, 1},
+	{"bottom", 50, 99, 5, 20, false, true, 50.5, 1, 50.5, 99},
+}
+
+var testWorld = Screen{Width: 100, Height: 100, CenterX: 50, CenterY: 50}
+
+func approx(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+func TestProjectileReflectsAtEachEdge(t *testing.T) {
+	for _, tt := range edgeCases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Projectile{X: tt.x, Y: tt.y, VX: tt.vx, VY: tt.vy, Lifetime: ProjectileLifetime}
+			ctx := UpdateContext{Delta: 100 * time.Millisecond, Screen: testWorld, Bounded: true}
+			if remove, _ := p.Update(ctx); remove {
+				t.Fatal("projectile removed on bounce")
+			}
+			if !approx(p.X, tt.boundX) || !approx(p.Y, tt.boundY) {
+				t.Errorf("position = (%v, %v), want (%v, %v)", p.X, p.Y, tt.boundX, tt.boundY)
+			}
+			wantVX, wantVY := tt.vx, tt.vy
+			if tt.flipX {
+				wantVX = -wantVX
+			}
+			if tt.flipY {
+				wantVY = -wantVY
+			}
+			if !approx(p.VX, wantVX) || !approx(p.VY, wantVY) {
+				t.Errorf("velocity = (%v, %v), want (%v, %v)", p.VX, p.VY, wantVX, wantVY)
+			}
+			if !approx(math.Hypot(p.VX, p.VY), math.Hypot(tt.vx, tt.vy)) {
+				t.Errorf("speed changed from %v to %v", math.Hypot(tt.vx, tt.vy), math.Hypot(p.VX, p.VY))
+			}
+			if !approx(p.Lifetime, ProjectileLifetime-0.1) {
+				t.Errorf("lifetime = %v, want %v: a bounce must not extend it", p.Lifetime, ProjectileLifetime-0.1)
+			}
+		})
+	}
+}
+
+func TestAsteroidReflectsAtEachEdge(t *testing.T) {
+	for _, tt := range edgeCases {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAsteroid(tt.x, tt.y, AsteroidSmall, 0)
+			a.VX, a.VY = tt.vx, tt.vy
+			ctx := UpdateContext{Delta: 100 * time.Millisecond, Screen: testWorld, Bounded: true}
+			a.Update(ctx)
+			if !approx(a.X, tt.boundX) || !approx(a.Y, tt.boundY) {
+				t.Errorf("position = (%v, %v), want (%v, %v)", a.X, a.Y, tt.boundX, tt.boundY)
+			}
+			if !approx(math.Hypot(a.VX, a.VY), math.Hypot(tt.vx, tt.vy)) {
+				t.Errorf("speed changed from %v to %v", math.Hypot(tt.vx, tt.vy), math.Hypot(a.VX, a.VY))
+			}
+		})
+	}
+}
+
+func TestProjectileWrapsWhenUnbounded(t *testing.T) {
+	for _, tt := range edgeCases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Projectile{X: tt.x, Y: tt.y, VX: tt.vx, VY: tt.vy, Lifetime: ProjectileLifetime}
+			p.Update(UpdateContext{Delta: 100 * time.Millisecond, Screen: testWorld})
+			if !approx(p.X, tt.wrapX) || !approx(p.Y, tt.wrapY) {
+				t.Errorf("position = (%v, %v), want (%v, %v)", p.X, p.Y, tt.wrapX, tt.wrapY)
+			}
+			if p.VX != tt.vx || p.VY != tt.vy {
+				t.Errorf("velocity = (%v, %v), want unchanged (%v, %v)", p.VX, p.VY, tt.vx, tt.vy)
+			}
+		})
+	}
+}
+
+func TestReflectPositionOvershoot(t *testing.T) {
+	// A step longer than the world must still land inside it
+	x, y, vx, vy := 250.0, -300.0, 10.0, -10.0
+	testWorld.ReflectPosition(&x, &y, &vx, &vy)
+	if x < 0 || x > 100 || y < 0 || y > 100 {
+		t.Errorf("position = (%v, %v), want inside the world", x, y)
+	}
+}
+
+func TestToScreenBounded(t *testing.T) {
+	// Camera at the world's left edge; an object near the right edge is only
+	// in view across the wrap.
+	ctx := DrawContext{
+		Camera: Camera{X: 0, Y: 50},
+		View:   Screen{Width: 40, Height: 20},
+		World:  testWorld,
+	}
+	if got := ctx.ToScreen(95, 50); got.Count != 1 || !approx(got.Positions[0].X, 15) {
+		t.Errorf("wrapping ToScreen(95, 50) = %+v, want one copy at x=15", got)
+	}
+	ctx.Bounded = true
+	if got := ctx.ToScreen(95, 50); got.Count != 0 {
+		t.Errorf("bounded ToScreen(95, 50) = %+v, want no copies", got)
+	}
+	if got := ctx.ToScreen(5, 50); got.Count != 1 || !approx(got.Positions[0].X, 25) || !approx(got.Positions[0].Y, 10) {
+		t.Errorf("bounded ToScreen(5, 50) = %+v, want one position at (25, 10)", got)
+	}
+}
@@ -1,9 +1,13 @@
 package object
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 	"sync"
+
+	"github.com/tomz197/asteroids/internal/draw"
+	"github.com/tomz197/asteroids/internal/physics"
 )
 
 // particlePool is a sync.Pool for reusing Particle objects to reduce allocations.
@@ -13,13 +17,57 @@ var particlePool = sync.Pool{
 	},
 }
 
+// particleDragRate is the number of drag periods per second for particles
+// (Particle.Drag is the fraction of velocity kept per 1/60s).
+const particleDragRate = 60.0
+
 // Particle is a short-lived visual effect.
 type Particle struct {
 	X, Y        float64 // Position
 	VX, VY      float64 // Velocity
 	Lifetime    float64 // Seconds remaining
 	MaxLifetime float64 // Initial lifetime (for fade calculation)
-	Drag        float64 // Velocity decay (1.0 = no drag)
+	Drag        float64 // Fraction of velocity kept per 1/60s (1.0 = no drag)
+}
+
+// ParticleStyle selects how particles are drawn. Particles carry no symbol
+// of their own; the style is a rendering choice, so every client (and
+// operator) can theme them without touching the simulation.
+type ParticleStyle int
+
+const (
+	ParticleDots   ParticleStyle = iota // One pixel per particle (default)
+	ParticleSparks                      // Short streaks trailing behind fast particles
+	ParticleDense                       // 2x2 blobs that shrink to a pixel as they fade
+)
+
+// particleStyleNames holds the display name for each ParticleStyle, indexed by style.
+var particleStyleNames = [...]string{
+	ParticleDots:   "dots",
+	ParticleSparks: "sparks",
+	ParticleDense:  "dense",
+}
+
+// sparkTrail is how far back a spark's streak reaches, in seconds of travel.
+const sparkTrail = 0.04
+
+// String returns the style's display name.
+func (s ParticleStyle) String() string {
+	if s < 0 || int(s) >= len(particleStyleNames) {
+		return particleStyleNames[ParticleDots]
+	}
+	return particleStyleNames[s]
+}
+
+// ParticleStyleByName returns the style with the given name, or ParticleDots
+// and false if none matches.
+func ParticleStyleByName(name string) (ParticleStyle, bool) {
+	for i, n := range particleStyleNames {
+		if n == name {
+			return ParticleStyle(i), true
+		}
+	}
+	return ParticleDots, false
 }
 
 // NewParticle creates a single particle from the pool.
@@ -83,6 +131,86 @@ func SpawnThrust(x, y, angle float64, spawner Spawner) {
 	}
 }
 
+// ParticleBurst describes the particles of one kind of explosion.
+type ParticleBurst struct {
+	Count    int     // Particles per burst (0 disables the effect)
+	Speed    float64 // Base particle speed in logical units per second
+	Lifetime float64 // Base particle lifetime in seconds
+}
+
+// Spawn emits the burst as a circular explosion at (x, y).
+func (b ParticleBurst) Spawn(x, y float64, spawner Spawner) {
+	SpawnExplosion(x, y, b.Count, b.Speed, b.Lifetime, spawner)
+}
+
+// ExplosionConfig holds the particle bursts for every kind of explosion.
+type ExplosionConfig struct {
+	Asteroid    ParticleBurst // Count is per size step (small=1, medium=2, large=3)
+	ShipDeath   ParticleBurst // A ship being destroyed
+	Impact      ParticleBurst // A projectile hitting an asteroid
+	MuzzleFlash ParticleBurst // A ship firing
+	Hyperspace  ParticleBurst // A ship vanishing into, and reappearing from, hyperspace
+}
+
+// Scaled returns a copy with every particle count multiplied by f (rounded).
+// f = 0 disables all explosion particles.
+func (c ExplosionConfig) Scaled(f float64) ExplosionConfig {
+	scale := func(b ParticleBurst) ParticleBurst {
+		b.Count = int(math.Round(float64(b.Count) * f))
+		return b
+	}
+	return ExplosionConfig{
+		Asteroid:    scale(c.Asteroid),
+		ShipDeath:   scale(c.ShipDeath),
+		Impact:      scale(c.Impact),
+		MuzzleFlash: scale(c.MuzzleFlash),
+		Hyperspace:  scale(c.Hyperspace),
+	}
+}
+
+// Validate reports an error if any burst has a negative count, speed or lifetime.
+func (c ExplosionConfig) Validate() error {
+	bursts := []struct {
+		name string
+		b    ParticleBurst
+	}{
+		{"asteroid", c.Asteroid},
+		{"ship death", c.ShipDeath},
+		{"impact", c.Impact},
+		{"muzzle flash", c.MuzzleFlash},
+		{"hyperspace", c.Hyperspace},
+	}
+	for _, e := range bursts {
+		if e.b.Count < 0 || e.b.Speed < 0 || e.b.Lifetime < 0 {
+			return fmt.Errorf("%s explosion: count, speed and lifetime must be non-negative (got %d, %g, %g)",
+				e.name, e.b.Count, e.b.Speed, e.b.Lifetime)
+		}
+	}
+	return nil
+}
+
+// SpawnMuzzleFlash creates a few very short-lived particles at a ship's
+// nose, thrown forward in a narrow cone along the firing angle.
+// The shooter's velocity is inherited so the flash stays at the nose.
+func SpawnMuzzleFlash(x, y, angle, shooterVX, shooterVY float64, burst ParticleBurst, spawner Spawner) {
+	if spawner == nil {
+		return
+	}
+
+	for i := 0; i < burst.Count; i++ {
+		flashAngle := angle + (rand.Float64()-0.5)*0.8
+		speed := burst.Speed * (1 + rand.Float64()*0.66)
+		lifetime := burst.Lifetime * (1 + rand.Float64())
+
+		vx := shooterVX + math.Cos(flashAngle)*speed
+		vy := shooterVY + math.Sin(flashAngle)*speed
+
+		p := NewParticle(x, y, vx, vy, lifetime)
+		p.Drag = 0.8
+		spawner.Spawn(p)
+	}
+}
+
 // Update moves the particle and checks lifetime.
 func (p *Particle) Update(ctx UpdateContext) (bool, error) {
 	dt := ctx.Delta.Seconds()
@@ -93,11 +221,8 @@ func (p *Particle) Update(ctx UpdateContext) (bool, error) {
 		return true, nil // Remove particle
 	}
 
-	// Apply drag (frame-rate-independent linear approximation of exponential decay)
-	dragFactor := 1.0 - (1.0-p.Drag)*(dt*60)
-	if dragFactor < 0 {
-		dragFactor = 0
-	}
+	// Apply drag (Drag is specified per 1/60s, so measure dt in 60Hz frames)
+	dragFactor := physics.DragFactor(ctx.DragModel, p.Drag, dt*particleDragRate)
 	p.VX *= dragFactor
 	p.VY *= dragFactor
 
@@ -119,11 +244,48 @@ func (p *Particle) Draw(ctx DrawContext) error {
 	}
 
 	// Get screen positions (handles world wrapping)
-	positions := WorldToScreen(p.X, p.Y, ctx.Camera, ctx.View, ctx.World)
+	positions := ctx.ToScreen(p.X, p.Y)
 	for i := 0; i < positions.Count; i++ {
 		pos := positions.Positions[i]
-		ctx.Canvas.SetFloat(pos.X, pos.Y)
+		switch ctx.ParticleStyle {
+		case ParticleSparks:
+			tail := draw.Point{X: pos.X - p.VX*sparkTrail, Y: pos.Y - p.VY*sparkTrail}
+			ctx.Canvas.DrawLine(tail, pos)
+		case ParticleDense:
+			p.plot(ctx, pos.X, pos.Y)
+			if p.MaxLifetime > 0 && p.Lifetime/p.MaxLifetime >= 0.5 {
+				p.plot(ctx, pos.X+1, pos.Y)
+				p.plot(ctx, pos.X, pos.Y+1)
+				p.plot(ctx, pos.X+1, pos.Y+1)
+			}
+		default:
+			p.plot(ctx, pos.X, pos.Y)
+		}
 	}
 
 	return nil
 }
+
+// plot sets one pixel of the particle, in its heat color when enabled.
+func (p *Particle) plot(ctx DrawContext, x, y float64) {
+	if !ctx.ParticleHeat || p.MaxLifetime <= 0 {
+		ctx.Canvas.SetFloat(x, y)
+		return
+	}
+	r, g, b := particleHeat(p.Lifetime / p.MaxLifetime)
+	ctx.Canvas.SetColored(x, y, r, g, b)
+}
+
+// particleHeat returns the color of a particle with the given fraction of
+// its lifetime left: pale yellow when fresh, cooling through orange to dark
+// red. Steps are coarse so a frame uses only a few accent colors.
+func particleHeat(left float64) (r, g, b uint8) {
+	t := math.Round(min(max(left, 0), 1)*heatSteps) / heatSteps
+	lerp := func(cold, hot float64) uint8 {
+		return uint8(cold + (hot-cold)*t)
+	}
+	return lerp(170, 255), lerp(30, 230), lerp(20, 130)
+}
+
+// heatSteps is the number of distinct particleHeat colors.
+const heatSteps = 8
@@ -0,0 +1,191 @@
+package object
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/tomz197/asteroids/internal/draw"
+)
+
+// PowerUpKind selects what a power-up gives the ship that picks it up.
+type PowerUpKind int
+
+const (
+	PowerUpRapidFire  PowerUpKind = iota // Shorter time between shots (see RapidFireFactor)
+	PowerUpTripleShot                    // Three projectiles per shot (see TripleShotSpread)
+	PowerUpPierce                        // Shots fly on through small asteroids (see PiercePowerUp)
+)
+
+// powerUpKindNames holds the display name for each PowerUpKind, indexed by kind.
+var powerUpKindNames = [...]string{
+	PowerUpRapidFire:  "rapid fire",
+	PowerUpTripleShot: "triple shot",
+	PowerUpPierce:     "pierce",
+}
+
+// powerUpSymbols holds the letter drawn beside each kind's pickup.
+var powerUpSymbols = [...]string{
+	PowerUpRapidFire:  "R",
+	PowerUpTripleShot: "T",
+	PowerUpPierce:     "P",
+}
+
+// PowerUpKindCount is the number of power-up kinds.
+const PowerUpKindCount = len(powerUpKindNames)
+
+// String returns the kind's display name.
+func (k PowerUpKind) String() string {
+	if k < 0 || int(k) >= len(powerUpKindNames) {
+		return "unknown"
+	}
+	return powerUpKindNames[k]
+}
+
+const (
+	// RapidFireFactor scales User.FireRate while rapid fire is active.
+	RapidFireFactor = 0.5
+
+	// TripleShotSpread is the angle in radians between the middle shot of
+	// a triple shot and each side shot (about 9 degrees).
+	TripleShotSpread = 0.15
+
+	// PiercePowerUp is how many small asteroids each shot passes through
+	// while the pierce power-up is active. Only small asteroids can be
+	// pierced, and two keeps a shot from clearing a whole cluster.
+	PiercePowerUp = 2
+
+	// PowerUpRadius is how close a ship's hitbox must come to collect a pickup.
+	PowerUpRadius = 2.0
+
+	// powerUpSpeed is how fast pickups drift, in units per second.
+	powerUpSpeed = 3.0
+
+	// powerUpBlinkFrom is the lifetime left at which a pickup starts to
+	// blink, showing it is about to vanish.
+	powerUpBlinkFrom = 3.0
+
+	// powerUpBlinkFrequency is the blink rate of expiring pickups, in Hz.
+	powerUpBlinkFrequency = 4.0
+)
+
+// PowerUp is a drifting pickup that grants its kind to the first ship that
+// touches it, and vanishes after its lifetime.
+type PowerUp struct {
+	X, Y      float64 // Position
+	VX, VY    float64 // Velocity
+	Kind      PowerUpKind
+	Lifetime  float64 // Seconds left before it vanishes uncollected
+	collected bool    // Picked up; removed on the next update
+}
+
+// NewPowerUpRandom creates a pickup of the given kind at a random position
+// in the world, drifting in a random direction.
+func NewPowerUpRandom(screen Screen, kind PowerUpKind, lifetime float64) *PowerUp {
+	angle := rand.Float64() * 2 * math.Pi
+	return &PowerUp{
+		X:        rand.Float64() * float64(screen.Width),
+		Y:        rand.Float64() * float64(screen.Height),
+		VX:       math.Cos(angle) * powerUpSpeed,
+		VY:       math.Sin(angle) * powerUpSpeed,
+		Kind:     kind,
+		Lifetime: lifetime,
+	}
+}
+
+// MarkCollected marks the pickup as taken, so no other ship can collect it.
+func (p *PowerUp) MarkCollected() {
+	p.collected = true
+}
+
+// IsCollected reports whether the pickup was taken or has expired.
+func (p *PowerUp) IsCollected() bool {
+	return p.collected || p.Lifetime <= 0
+}
+
+// Update drifts the pickup and removes it once collected or expired.
+func (p *PowerUp) Update(ctx UpdateContext) (bool, error) {
+	dt := ctx.Delta.Seconds()
+	p.Lifetime -= dt
+	if p.IsCollected() {
+		return true, nil
+	}
+	p.X += p.VX * dt
+	p.Y += p.VY * dt
+	ctx.Confine(&p.X, &p.Y, &p.VX, &p.VY)
+	return false, nil
+}
+
+// Draw renders the pickup as a diamond with its kind's letter beside it,
+// blinking near the end of its lifetime.
+func (p *PowerUp) Draw(ctx DrawContext) error {
+	if p.Lifetime < powerUpBlinkFrom && !ShouldRenderBlink(p.Lifetime, powerUpBlinkFrequency) {
+		return nil
+	}
+	symbol := "?"
+	if p.Kind >= 0 && int(p.Kind) < len(powerUpSymbols) {
+		symbol = powerUpSymbols[p.Kind]
+	}
+
+	positions := ctx.ToScreen(p.X, p.Y)
+	for i := 0; i < positions.Count; i++ {
+		pos := positions.Positions[i]
+		points := ctx.Canvas.BorrowPoints(4)
+		points[0] = draw.Point{X: pos.X, Y: pos.Y - PowerUpRadius}
+		points[1] = draw.Point{X: pos.X + PowerUpRadius, Y: pos.Y}
+		points[2] = draw.Point{X: pos.X, Y: pos.Y + PowerUpRadius}
+		points[3] = draw.Point{X: pos.X - PowerUpRadius, Y: pos.Y}
+		ctx.Canvas.DrawPolygon(points, false)
+
+		col, row := ctx.Canvas.LogicalToTerminal(pos.X+PowerUpRadius*2, pos.Y)
+		ctx.Canvas.DrawText(col, row, symbol)
+	}
+	return nil
+}
+
+// GetPosition returns the pickup's center.
+func (p *PowerUp) GetPosition() (float64, float64) {
+	return p.X, p.Y
+}
+
+// GetRadius returns the pickup's collection radius.
+func (p *PowerUp) GetRadius() float64 {
+	return PowerUpRadius
+}
+
+// PowerUpSpawner keeps a few pickups in the world: every Interval seconds
+// it adds one of a random kind, unless Max are already out.
+type PowerUpSpawner struct {
+	Interval float64 // Seconds between spawns (<= 0 disables spawning)
+	Max      int     // Pickups allowed in the world at once
+	Lifetime float64 // Seconds each pickup stays before vanishing
+	timer    float64 // Seconds since the last spawn attempt
+}
+
+// Update spawns a pickup when the interval has elapsed and there is room.
+func (s *PowerUpSpawner) Update(ctx UpdateContext) (bool, error) {
+	if s.Interval <= 0 || ctx.Spawner == nil {
+		return false, nil
+	}
+	s.timer += ctx.Delta.Seconds()
+	if s.timer < s.Interval {
+		return false, nil
+	}
+	s.timer = 0
+
+	count := 0
+	for _, obj := range ctx.Objects {
+		if _, ok := obj.(*PowerUp); ok {
+			count++
+		}
+	}
+	if count < s.Max {
+		kind := PowerUpKind(rand.Intn(PowerUpKindCount))
+		ctx.Spawner.Spawn(NewPowerUpRandom(ctx.Screen, kind, s.Lifetime))
+	}
+	return false, nil
+}
+
+// Draw is a no-op; the spawner is not visible.
+func (s *PowerUpSpawner) Draw(_ DrawContext) error {
+	return nil
+}
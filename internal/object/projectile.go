@@ -2,6 +2,8 @@ package object
 
 import (
 	"math"
+
+	"github.com/tomz197/asteroids/internal/draw"
 )
 
 // Projectile is a bullet fired by the player.
@@ -11,6 +13,8 @@ type Projectile struct {
 	Lifetime  float64 // Seconds remaining before removal
 	Symbol    rune    // Character to display
 	OwnerID   int     // Client ID that fired this projectile
+	Pierce    int     // Small asteroids it can still destroy and fly on through (0 = single hit)
+	Hit       bool    // Has hit something already, so a piercing shot counts once toward its owner's accuracy
 	destroyed bool    // Marked for destruction
 }
 
@@ -23,6 +27,49 @@ const ProjectileLifetime = 2.0
 // ProjectileRadius is the collision radius for projectile-projectile collisions.
 const ProjectileRadius = 0.5
 
+// ProjectileStyle selects how projectiles are drawn.
+type ProjectileStyle int
+
+const (
+	ProjectileDot    ProjectileStyle = iota // One pixel (default)
+	ProjectileTracer                        // Short streak behind the bullet along its velocity
+	ProjectilePlus                          // Small plus sign
+)
+
+// projectileStyleNames holds the display name for each ProjectileStyle, indexed by style.
+var projectileStyleNames = [...]string{
+	ProjectileDot:    "dot",
+	ProjectileTracer: "tracer",
+	ProjectilePlus:   "plus",
+}
+
+// projectileFadeFrom is the fraction of ProjectileLifetime left at which a
+// projectile starts to fade (or blink), showing it is about to expire.
+const projectileFadeFrom = 0.25
+
+// tracerTrail is how far back a tracer reaches, in seconds of travel
+// (about 2-3 pixels at ProjectileSpeed in the default view).
+const tracerTrail = 0.05
+
+// String returns the style's display name.
+func (s ProjectileStyle) String() string {
+	if s < 0 || int(s) >= len(projectileStyleNames) {
+		return projectileStyleNames[ProjectileDot]
+	}
+	return projectileStyleNames[s]
+}
+
+// ProjectileStyleByName returns the style with the given name, or
+// ProjectileDot and false if none matches.
+func ProjectileStyleByName(name string) (ProjectileStyle, bool) {
+	for i, n := range projectileStyleNames {
+		if n == name {
+			return ProjectileStyle(i), true
+		}
+	}
+	return ProjectileDot, false
+}
+
 // NewProjectile creates a projectile at position (x,y) traveling in direction angle.
 // The projectile inherits the shooter's velocity plus its own speed.
 // ownerID identifies the client that fired it (for score attribution).
@@ -63,19 +110,43 @@ func (p *Projectile) Update(ctx UpdateContext) (bool, error) {
 	p.X += p.VX * dt
 	p.Y += p.VY * dt
 
-	// Screen wrapping
-	ctx.Screen.WrapPosition(&p.X, &p.Y)
+	// Wrap around (or bounce off) world edges
+	ctx.Confine(&p.X, &p.Y, &p.VX, &p.VY)
 
 	return false, nil
 }
 
-// Draw renders the projectile.
+// Draw renders the projectile. Near the end of its range it is drawn in
+// ctx.ExpiringColor, or blinks when that is unset.
 func (p *Projectile) Draw(ctx DrawContext) error {
+	if p.Lifetime < ProjectileLifetime*projectileFadeFrom {
+		if ctx.ExpiringColor == "" {
+			if !ShouldRenderBlink(p.Lifetime, 10.0) {
+				return nil
+			}
+		} else {
+			ctx.Canvas.SetAccent(ctx.ExpiringColor)
+			defer ctx.Canvas.SetAccent("")
+		}
+	}
+
 	// Get screen positions (handles world wrapping)
-	positions := WorldToScreen(p.X, p.Y, ctx.Camera, ctx.View, ctx.World)
+	positions := ctx.ToScreen(p.X, p.Y)
 	for i := 0; i < positions.Count; i++ {
 		pos := positions.Positions[i]
-		ctx.Canvas.SetFloat(pos.X, pos.Y)
+		switch ctx.ProjectileStyle {
+		case ProjectileTracer:
+			tail := draw.Point{X: pos.X - p.VX*tracerTrail, Y: pos.Y - p.VY*tracerTrail}
+			ctx.Canvas.DrawLine(tail, pos)
+		case ProjectilePlus:
+			ctx.Canvas.SetFloat(pos.X, pos.Y)
+			ctx.Canvas.SetFloat(pos.X-1, pos.Y)
+			ctx.Canvas.SetFloat(pos.X+1, pos.Y)
+			ctx.Canvas.SetFloat(pos.X, pos.Y-1)
+			ctx.Canvas.SetFloat(pos.X, pos.Y+1)
+		default:
+			ctx.Canvas.SetFloat(pos.X, pos.Y)
+		}
 	}
 
 	return nil
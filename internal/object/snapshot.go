@@ -0,0 +1,63 @@
+package object
+
+// CopyObjects returns value copies of objs for a world snapshot, in the same
+// order, plus the copied ships. Asteroids, projectiles, particles, power-ups
+// and ships are copied into one backing slice per type (a few allocations rather than
+// one per object). The spawners are left out: they draw nothing, and they
+// change every tick (the asteroid spawner ramps toward its target, and is
+// removed when waves take over). Labels never change after creation and are
+// shared.
+//
+// The copies hold everything Draw needs (position, angle, shape, size,
+// lifetime, owner), so rendering them never touches objects the simulation
+// is updating, and pooled objects can be reused freely once copied.
+func CopyObjects(objs []Object) ([]Object, []*User) {
+	var nAsteroids, nProjectiles, nParticles, nPowerUps, nUsers int
+	for _, obj := range objs {
+		switch obj.(type) {
+		case *Asteroid:
+			nAsteroids++
+		case *Projectile:
+			nProjectiles++
+		case *Particle:
+			nParticles++
+		case *PowerUp:
+			nPowerUps++
+		case *User:
+			nUsers++
+		}
+	}
+	asteroids := make([]Asteroid, 0, nAsteroids)
+	projectiles := make([]Projectile, 0, nProjectiles)
+	particles := make([]Particle, 0, nParticles)
+	powerUps := make([]PowerUp, 0, nPowerUps)
+	users := make([]User, 0, nUsers)
+	userPtrs := make([]*User, 0, nUsers)
+
+	out := make([]Object, 0, len(objs))
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *Asteroid:
+			asteroids = append(asteroids, *o)
+			out = append(out, &asteroids[len(asteroids)-1])
+		case *Projectile:
+			projectiles = append(projectiles, *o)
+			out = append(out, &projectiles[len(projectiles)-1])
+		case *Particle:
+			particles = append(particles, *o)
+			out = append(out, &particles[len(particles)-1])
+		case *PowerUp:
+			powerUps = append(powerUps, *o)
+			out = append(out, &powerUps[len(powerUps)-1])
+		case *User:
+			users = append(users, *o)
+			out = append(out, &users[len(users)-1])
+			userPtrs = append(userPtrs, &users[len(users)-1])
+		case *AsteroidSpawner, *PowerUpSpawner:
+			// Invisible and mutable; see above
+		default:
+			out = append(out, obj)
+		}
+	}
+	return out, userPtrs
+}
@@ -0,0 +1,53 @@
+package object
+
+import "testing"
+
+func TestCopyObjects(t *testing.T) {
+	asteroid := NewAsteroid(10, 20, AsteroidLarge, 0)
+	shot := &Projectile{X: 1, Y: 2, Lifetime: 1}
+	ship := &User{X: 5, Y: 6}
+	label := &Label{X: 7, Y: 8, Text: "HI"}
+	spawner := NewAsteroidSpawner(AsteroidSpawnerConfig{Initial: 4, Target: 8})
+	objs := []Object{asteroid, spawner, shot, label, ship, &PowerUpSpawner{}}
+
+	out, users := CopyObjects(objs)
+
+	want := []Object{asteroid, shot, label, ship}
+	if len(out) != len(want) {
+		t.Fatalf("got %d objects, want %d (spawners left out)", len(out), len(want))
+	}
+	for i, obj := range out {
+		switch o := obj.(type) {
+		case *Asteroid:
+			if o == asteroid || o.X != asteroid.X || o.Y != asteroid.Y || o.Size != asteroid.Size {
+				t.Errorf("object %d: want a copy of the asteroid, got %p (original %p)", i, o, asteroid)
+			}
+		case *Projectile:
+			if o == shot || *o != *shot {
+				t.Errorf("object %d: want a copy of the projectile, got %p (original %p)", i, o, shot)
+			}
+		case *Label:
+			if o != label {
+				t.Errorf("object %d: want the shared label", i)
+			}
+		case *User:
+			if o == ship || o.X != ship.X || o.Y != ship.Y {
+				t.Errorf("object %d: want a copy of the ship, got %p (original %p)", i, o, ship)
+			}
+		default:
+			t.Errorf("object %d: unexpected %T", i, obj)
+		}
+		if _, isCopy := obj.(*Label); !isCopy && obj == want[i] {
+			t.Errorf("object %d is the original, want a copy", i)
+		}
+	}
+	if len(users) != 1 || users[0] != out[3] {
+		t.Errorf("users = %v, want the copied ship", users)
+	}
+
+	// Later changes to the originals don't reach the snapshot
+	asteroid.X = 99
+	if out[0].(*Asteroid).X == 99 {
+		t.Error("snapshot asteroid follows the original")
+	}
+}
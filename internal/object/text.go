@@ -1,34 +1,22 @@
 package object
 
-import (
-	"fmt"
-)
-
 // Text is a simple drawable text object.
-// Coordinates are 1-based terminal positions (not sub-pixel coordinates).
+// Coordinates are 1-based terminal positions within the canvas area
+// (not sub-pixel coordinates).
 type Text struct {
 	X     int
 	Y     int
 	Value string
 }
 
-// Draw writes the text at its position using ANSI cursor movement.
-// Text uses the Writer directly since it needs actual text characters.
+// Draw queues the text on the canvas, which writes it on top of the rendered
+// frame and cleans it up on the next frame once it is no longer drawn.
+// Text outside the canvas area is clipped.
 func (t Text) Draw(ctx DrawContext) error {
 	if t.Value == "" {
 		return nil
 	}
-	x := t.X
-	y := t.Y
-	if x < 1 {
-		x = 1
-	}
-	if y < 1 {
-		y = 1
-	}
-	if _, err := fmt.Fprintf(ctx.Writer, "\033[%d;%dH%s", y, x, t.Value); err != nil {
-		return err
-	}
+	ctx.Canvas.DrawText(t.X, t.Y, t.Value)
 	return nil
 }
 
@@ -2,31 +2,191 @@ package object
 
 import (
 	"math"
+	"math/rand"
 
 	"github.com/tomz197/asteroids/internal/draw"
+	"github.com/tomz197/asteroids/internal/physics"
 )
 
+// ShipShape identifies a cosmetic ship silhouette. Shapes don't affect the
+// hitbox, which is always derived from Size (see GetRadius).
+type ShipShape int
+
+const (
+	ShipClassic ShipShape = iota // Classic Asteroids triangle
+	ShipArrow                    // Arrowhead with a notched tail
+	ShipDelta                    // Wide delta wing
+)
+
+// shipVertex is a silhouette vertex in polar form relative to the ship's
+// heading: angle offset from the nose in radians, distance as a fraction of Size.
+type shipVertex struct {
+	angle, scale float64
+}
+
+// shipShapes holds the vertex table for each ShipShape, indexed by shape.
+var shipShapes = [...][]shipVertex{
+	ShipClassic: {{0, 1}, {2.5, 0.7}, {-2.5, 0.7}},
+	ShipArrow:   {{0, 1}, {2.4, 0.8}, {math.Pi, 0.2}, {-2.4, 0.8}},
+	ShipDelta:   {{0, 1}, {2.3, 1}, {2.9, 0.55}, {-2.9, 0.55}, {-2.3, 1}},
+}
+
+// shipShapeNames holds the display name for each ShipShape, indexed by shape.
+var shipShapeNames = [...]string{
+	ShipClassic: "classic",
+	ShipArrow:   "arrow",
+	ShipDelta:   "delta",
+}
+
+// ShipShapeCount is the number of available ship shapes.
+const ShipShapeCount = len(shipShapes)
+
+// String returns the shape's display name.
+func (s ShipShape) String() string {
+	if s < 0 || int(s) >= len(shipShapeNames) {
+		return shipShapeNames[ShipClassic]
+	}
+	return shipShapeNames[s]
+}
+
+// ShipShapeByName returns the shape with the given name, or ShipClassic and
+// false if none matches.
+func ShipShapeByName(name string) (ShipShape, bool) {
+	for i, n := range shipShapeNames {
+		if n == name {
+			return ShipShape(i), true
+		}
+	}
+	return ShipClassic, false
+}
+
+// FireMode selects how holding the fire key shoots.
+type FireMode int
+
+const (
+	FireAuto FireMode = iota // Holding fire shoots at the fire rate (default)
+	FireTap                  // One shot per press (Input.FirePress)
+)
+
+// fireModeNames holds the display name for each FireMode, indexed by mode.
+var fireModeNames = [...]string{
+	FireAuto: "auto",
+	FireTap:  "tap",
+}
+
+// String returns the mode's display name.
+func (m FireMode) String() string {
+	if m < 0 || int(m) >= len(fireModeNames) {
+		return fireModeNames[FireAuto]
+	}
+	return fireModeNames[m]
+}
+
+// Valid reports whether m is a known fire mode.
+func (m FireMode) Valid() bool {
+	return m >= 0 && int(m) < len(fireModeNames)
+}
+
+// ShipColorCount is the number of ship color slots (see User.Color and
+// DrawContext.ShipColors).
+const ShipColorCount = 6
+
 // User is the player-controlled spaceship (Asteroids-style).
 type User struct {
 	X, Y   float64 // Position (center of ship)
 	VX, VY float64 // Velocity (momentum)
 	Angle  float64 // Rotation in radians (0 = pointing right, increases counter-clockwise)
 
-	ThrustPower   float64 // Acceleration when thrusting
-	RotationSpeed float64 // Radians per second
-	MaxSpeed      float64 // Maximum velocity magnitude
-	Drag          float64 // Velocity decay per second (1.0 = no drag, 0.5 = 50% speed loss/sec)
-	Size          float64 // Size of the ship triangle
+	ThrustPower   float64   // Acceleration when thrusting
+	RotationSpeed float64   // Radians per second
+	MaxSpeed      float64   // Maximum velocity magnitude
+	Drag          float64   // Fraction of velocity kept per second (1.0 = no drag, 0.5 = 50% speed loss/sec)
+	Size          float64   // Size of the ship triangle
+	Shape         ShipShape // Cosmetic silhouette (hitbox is unaffected)
+	Color         uint8     // Color slot (0 to ShipColorCount-1), assigned per client on spawn
 
 	// Shooting
 	FireRate     float64 // Minimum seconds between shots
 	fireCooldown float64 // Time until next shot allowed
+	Pierce       int     // Small asteroids each shot passes through (see Projectile.Pierce)
+
+	// Weapon power-ups: seconds left on each (see ApplyPowerUp)
+	RapidFireTime  float64
+	TripleShotTime float64
+	PierceTime     float64
+
+	// Hyperspace state (see HyperspaceConfig)
+	hyperCooldown float64 // Seconds until the next jump is allowed
+	jumped        bool    // Jumped during the last Update
+	jumpSafe      bool    // The last jump landed at a random spot, not on an asteroid
+
+	// Turn ramp state (see TurnRamp)
+	turnDir  int     // Direction of the current turn: -1 left, 1 right, 0 none
+	turnHeld float64 // Seconds the current turn has been held
+	turnIdle float64 // Seconds since the turn key was last seen
 
 	// Ownership
 	OwnerID  int    // Client ID that owns this ship (for projectile attribution)
 	Username string // Display name shown above the ship
 }
 
+// TurnRamp scales turning speed by how long a turn key has been held, so a
+// tap nudges the ship a little and holding spins it at full RotationSpeed.
+// The factor rises from Start to 1 over Time seconds, shaped by Exponent
+// (1 = linear, >1 = slow start). The zero value disables the ramp.
+type TurnRamp struct {
+	Start    float64 // Fraction of RotationSpeed when a turn begins (0-1)
+	Time     float64 // Seconds of holding to reach full speed
+	Exponent float64 // Curve shape; 0 is treated as 1
+	Grace    float64 // Seconds a key may go unseen (terminal key-repeat gaps) before the turn counts as released
+}
+
+// HyperspaceConfig controls the hyperspace jump (see User.Update).
+type HyperspaceConfig struct {
+	Cooldown float64 // Seconds between jumps
+	Risk     float64 // Chance (0-1) a jump lands inside an asteroid
+}
+
+// Factor returns the fraction of full turning speed after held seconds.
+func (r TurnRamp) Factor(held float64) float64 {
+	if r.Time <= 0 || held >= r.Time {
+		return 1
+	}
+	exp := r.Exponent
+	if exp <= 0 {
+		exp = 1
+	}
+	return r.Start + (1-r.Start)*math.Pow(held/r.Time, exp)
+}
+
+// updateTurn advances the turn ramp for this tick and returns the turn
+// direction (-1, 0 or 1) and the speed factor to apply, averaged over the
+// tick.
+func (u *User) updateTurn(in Input, ramp TurnRamp, dt float64) (dir int, factor float64) {
+	if in.Left || in.UpLeft {
+		dir--
+	}
+	if in.Right || in.UpRight {
+		dir++
+	}
+	if dir == 0 {
+		u.turnIdle += dt
+		if u.turnIdle > ramp.Grace {
+			u.turnDir, u.turnHeld = 0, 0
+		}
+		return 0, 0
+	}
+	if dir != u.turnDir {
+		u.turnDir, u.turnHeld = dir, 0
+	}
+	u.turnIdle = 0
+	// Average the ramp over the tick, so the angle turned doesn't depend on
+	// the tick length
+	factor = (ramp.Factor(u.turnHeld) + ramp.Factor(u.turnHeld+dt)) / 2
+	u.turnHeld += dt
+	return dir, factor
+}
+
 // NewUser creates a new spaceship at the given position.
 func NewUser(x, y float64) *User {
 	return &User{
@@ -46,17 +206,23 @@ func NewUser(x, y float64) *User {
 func (u *User) Update(ctx UpdateContext) (bool, error) {
 	dt := ctx.Delta.Seconds()
 
-	// Rotation (left/right)
-	if ctx.Input.Left || ctx.Input.UpLeft {
-		u.Angle -= u.RotationSpeed * dt
-	}
-	if ctx.Input.Right || ctx.Input.UpRight {
-		u.Angle += u.RotationSpeed * dt
+	// Hyperspace jump, once the cooldown allows
+	u.jumped = false
+	u.hyperCooldown = max(u.hyperCooldown-dt, 0)
+	if ctx.Input.Hyperspace && u.hyperCooldown <= 0 {
+		u.hyperspace(ctx)
 	}
 
+	// Rotation (left/right), ramped up while the key is held
+	dir, factor := u.updateTurn(ctx.Input, ctx.TurnRamp, dt)
+	u.Angle += float64(dir) * u.RotationSpeed * factor * dt
+
 	// Normalize angle to [-π, π] in O(1)
 	u.Angle = math.Remainder(u.Angle, 2*math.Pi)
 
+	// Velocity at the start of the tick, for integrating position below
+	startVX, startVY := u.VX, u.VY
+
 	// Thrust (accelerate in facing direction)
 	if ctx.Input.Up || ctx.Input.UpLeft || ctx.Input.UpRight {
 		u.VX += math.Cos(u.Angle) * u.ThrustPower * dt
@@ -68,12 +234,12 @@ func (u *User) Update(ctx UpdateContext) (bool, error) {
 		SpawnThrust(backX, backY, u.Angle, ctx.Spawner)
 	}
 
+	// Planet mode: pull toward the world center
+	ctx.Pull(u.X, u.Y, &u.VX, &u.VY, dt)
+
 	// Apply drag (velocity decay when not thrusting)
 	if !ctx.Input.Up && !ctx.Input.UpLeft && !ctx.Input.UpRight {
-		dragFactor := 1.0 - (1.0-u.Drag)*dt
-		if dragFactor < 0 {
-			dragFactor = 0
-		}
+		dragFactor := physics.DragFactor(ctx.DragModel, u.Drag, dt)
 		u.VX *= dragFactor
 		u.VY *= dragFactor
 	}
@@ -86,33 +252,144 @@ func (u *User) Update(ctx UpdateContext) (bool, error) {
 		u.VY *= scale
 	}
 
-	// Apply velocity to position
-	u.X += u.VX * dt
-	u.Y += u.VY * dt
+	// Apply velocity to position using the average of the start and end
+	// velocities (after thrust, drag and clamping). This is exact for constant
+	// acceleration, so distance covered while accelerating or coasting to a
+	// stop no longer depends on the tick length.
+	u.X += (startVX + u.VX) * 0.5 * dt
+	u.Y += (startVY + u.VY) * 0.5 * dt
+
+	// Wrap around (or bounce off) world edges
+	ctx.Confine(&u.X, &u.Y, &u.VX, &u.VY)
 
-	// Screen wrapping
-	ctx.Screen.WrapPosition(&u.X, &u.Y)
+	// Weapon power-ups run out
+	u.RapidFireTime = max(u.RapidFireTime-dt, 0)
+	u.TripleShotTime = max(u.TripleShotTime-dt, 0)
+	u.PierceTime = max(u.PierceTime-dt, 0)
 
 	// Shooting
 	u.fireCooldown -= dt
-	if ctx.Input.Space && u.fireCooldown <= 0 && ctx.Spawner != nil {
-		u.fireCooldown = u.FireRate
+	if u.wantsFire(ctx) && u.fireCooldown <= 0 && ctx.Spawner != nil {
+		u.fireCooldown = u.fireInterval()
 
-		// Spawn projectile from the nose of the ship
+		// Spawn projectiles from the nose of the ship; a triple shot adds
+		// one to each side
 		noseX := u.X + math.Cos(u.Angle)*u.Size
 		noseY := u.Y + math.Sin(u.Angle)*u.Size
-
-		projectile := NewProjectile(noseX, noseY, u.Angle, u.VX, u.VY, u.OwnerID)
-		ctx.Spawner.Spawn(projectile)
+		angles := [3]float64{u.Angle, u.Angle - TripleShotSpread, u.Angle + TripleShotSpread}
+		shots := 1
+		if u.TripleShotTime > 0 {
+			shots = len(angles)
+		}
+		for _, angle := range angles[:shots] {
+			projectile := NewProjectile(noseX, noseY, angle, u.VX, u.VY, u.OwnerID)
+			projectile.Pierce = u.shotPierce()
+			ctx.Spawner.Spawn(projectile)
+		}
+		SpawnMuzzleFlash(noseX, noseY, u.Angle, u.VX, u.VY, ctx.Explosions.MuzzleFlash, ctx.Spawner)
 	}
 
 	return false, nil
 }
 
+// hyperspace moves the ship to a random spot in the world and stops it.
+// With chance ctx.Hyperspace.Risk it lands on a random asteroid instead,
+// which the collision check then treats as a crash.
+func (u *User) hyperspace(ctx UpdateContext) {
+	u.hyperCooldown = ctx.Hyperspace.Cooldown
+	ctx.Explosions.Hyperspace.Spawn(u.X, u.Y, ctx.Spawner)
+
+	x := rand.Float64() * float64(ctx.Screen.Width)
+	y := rand.Float64() * float64(ctx.Screen.Height)
+	u.jumpSafe = true
+	if ctx.Hyperspace.Risk > 0 && rand.Float64() < ctx.Hyperspace.Risk {
+		if a := randomAsteroid(ctx.Objects); a != nil {
+			x, y = a.X, a.Y
+			u.jumpSafe = false
+		}
+	}
+
+	u.X, u.Y = x, y
+	u.VX, u.VY = 0, 0
+	u.jumped = true
+	ctx.Explosions.Hyperspace.Spawn(u.X, u.Y, ctx.Spawner)
+}
+
+// randomAsteroid returns a uniformly chosen asteroid that can be collided
+// with, or nil if there is none.
+func randomAsteroid(objects []Object) *Asteroid {
+	var picked *Asteroid
+	seen := 0
+	for _, obj := range objects {
+		a, ok := obj.(*Asteroid)
+		if !ok || a.IsDestroyed() || a.IsProtected() {
+			continue
+		}
+		seen++
+		if rand.Intn(seen) == 0 {
+			picked = a
+		}
+	}
+	return picked
+}
+
+// Hyperspaced reports whether the ship jumped through hyperspace during its
+// last Update, and whether it landed clear rather than inside an asteroid.
+func (u *User) Hyperspaced() (jumped, safe bool) {
+	return u.jumped, u.jumpSafe
+}
+
+// fireInterval returns the seconds between shots: FireRate, shortened by
+// RapidFireFactor while rapid fire is active.
+func (u *User) fireInterval() float64 {
+	if u.RapidFireTime > 0 {
+		return u.FireRate * RapidFireFactor
+	}
+	return u.FireRate
+}
+
+// shotPierce returns how many small asteroids a new shot passes through:
+// Pierce, raised to PiercePowerUp while the pierce power-up is active.
+func (u *User) shotPierce() int {
+	if u.PierceTime > 0 {
+		return max(u.Pierce, PiercePowerUp)
+	}
+	return u.Pierce
+}
+
+// ApplyPowerUp grants a weapon power-up for the given seconds. Picking up
+// one that is already active extends it to at least that long.
+func (u *User) ApplyPowerUp(kind PowerUpKind, seconds float64) {
+	switch kind {
+	case PowerUpRapidFire:
+		u.RapidFireTime = max(u.RapidFireTime, seconds)
+	case PowerUpTripleShot:
+		u.TripleShotTime = max(u.TripleShotTime, seconds)
+	case PowerUpPierce:
+		u.PierceTime = max(u.PierceTime, seconds)
+	}
+}
+
+// wantsFire reports whether the input asks for a shot under the player's
+// fire mode.
+func (u *User) wantsFire(ctx UpdateContext) bool {
+	if ctx.FireMode == FireTap {
+		return ctx.Input.FirePress
+	}
+	return ctx.Input.Fire
+}
+
 // Draw renders the spaceship as a triangle pointing in the direction of travel.
 func (u *User) Draw(ctx DrawContext) error {
+	if n := len(ctx.ShipColors); n > 0 {
+		if color := ctx.ShipColors[int(u.Color)%n]; color != "" {
+			ctx.Canvas.SetAccent(color)
+			defer ctx.Canvas.SetAccent("")
+		}
+	}
+
 	// Get screen positions (handles world wrapping)
-	positions := WorldToScreen(u.X, u.Y, ctx.Camera, ctx.View, ctx.World)
+	positions := ctx.ToScreen(u.X, u.Y)
 	for i := 0; i < positions.Count; i++ {
 		pos := positions.Positions[i]
 		u.drawAt(ctx, pos.X, pos.Y)
@@ -122,26 +399,25 @@ func (u *User) Draw(ctx DrawContext) error {
 }
 
 // drawAt draws the ship at a specific screen position.
+// Vertices come from the shape's table, rotated so the nose points along Angle.
 func (u *User) drawAt(ctx DrawContext, screenX, screenY float64) {
-	// Triangle vertices relative to center:
-	// - Nose (front): in the direction of Angle
-	// - Left wing: 140° from nose
-	// - Right wing: -140° from nose
-	noseAngle := u.Angle
-	leftAngle := u.Angle + 2.5 // ~143 degrees
-	rightAngle := u.Angle - 2.5
+	verts := shipShapes[ShipClassic]
+	if u.Shape >= 0 && int(u.Shape) < len(shipShapes) {
+		verts = shipShapes[u.Shape]
+	}
 
 	size := u.Size
 
 	// Use reusable buffer from canvas to avoid per-frame allocations.
 	// Safe for concurrent rendering because each client has its own Canvas.
-	triangle := ctx.Canvas.BorrowPoints(3)
-	triangle[0] = draw.Point{X: screenX + math.Cos(noseAngle)*size, Y: screenY + math.Sin(noseAngle)*size}
-	triangle[1] = draw.Point{X: screenX + math.Cos(leftAngle)*size*0.7, Y: screenY + math.Sin(leftAngle)*size*0.7}
-	triangle[2] = draw.Point{X: screenX + math.Cos(rightAngle)*size*0.7, Y: screenY + math.Sin(rightAngle)*size*0.7}
+	points := ctx.Canvas.BorrowPoints(len(verts))
+	for i, v := range verts {
+		sin, cos := math.Sincos(u.Angle + v.angle)
+		points[i] = draw.Point{X: screenX + cos*size*v.scale, Y: screenY + sin*size*v.scale}
+	}
 
-	// Draw the triangle to canvas
-	ctx.Canvas.DrawPolygon(triangle, true)
+	// Draw the ship to canvas
+	ctx.Canvas.DrawPolygon(points, true)
 }
 
 // GetPosition returns the ship's center position.
@@ -0,0 +1,125 @@
+package object
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPiercePowerUp(t *testing.T) {
+	u := NewUser(50, 50)
+	u.ApplyPowerUp(PowerUpPierce, 1)
+
+	fire := func() *Projectile {
+		t.Helper()
+		var spawned spawnRecorder
+		u.fireCooldown = 0
+		u.Update(UpdateContext{
+			Delta:   100 * time.Millisecond,
+			Input:   Input{Fire: true},
+			Screen:  testWorld,
+			Spawner: &spawned,
+		})
+		for _, obj := range spawned {
+			if p, ok := obj.(*Projectile); ok {
+				return p
+			}
+		}
+		t.Fatal("no projectile fired")
+		return nil
+	}
+
+	if p := fire(); p.Pierce != PiercePowerUp {
+		t.Errorf("shot Pierce = %d with the power-up, want %d", p.Pierce, PiercePowerUp)
+	}
+	u.PierceTime = 0
+	if p := fire(); p.Pierce != 0 {
+		t.Errorf("shot Pierce = %d after the power-up ran out, want 0", p.Pierce)
+	}
+
+	// A base pierce above the power-up's is kept
+	u.Pierce = PiercePowerUp + 1
+	u.ApplyPowerUp(PowerUpPierce, 1)
+	if p := fire(); p.Pierce != PiercePowerUp+1 {
+		t.Errorf("shot Pierce = %d, want the higher base %d", p.Pierce, PiercePowerUp+1)
+	}
+
+	// Picking it up again extends it, never shortens it
+	u.PierceTime = 5
+	u.ApplyPowerUp(PowerUpPierce, 1)
+	if u.PierceTime != 5 {
+		t.Errorf("PierceTime = %v after a shorter pickup, want 5", u.PierceTime)
+	}
+}
+
+// tickSchedules are the tick lengths ship kinematics must agree across:
+// steady 30 and 60 fps, and a tick that jitters between 10ms and 45ms.
+var tickSchedules = []struct {
+	name string
+	next func(i int) time.Duration
+}{
+	{"30fps", func(int) time.Duration { return time.Second / 30 }},
+	{"60fps", func(int) time.Duration { return time.Second / 60 }},
+	{"jittered", func(i int) time.Duration {
+		return 10*time.Millisecond + time.Duration(i*7919%36)*time.Millisecond
+	}},
+}
+
+// flyShip updates a ship for total time with the given input, in ticks from
+// next, and returns the angle it turned. The last tick is shortened to end
+// exactly at total.
+func flyShip(u *User, in Input, total time.Duration, next func(i int) time.Duration) (turned float64) {
+	world := Screen{Width: 100000, Height: 100000}
+	ramp := TurnRamp{Start: 0.4, Time: 0.3, Exponent: 1.5}
+	var spawned spawnRecorder
+	for i, elapsed := 0, time.Duration(0); elapsed < total; i++ {
+		dt := min(next(i), total-elapsed)
+		elapsed += dt
+		before := u.Angle
+		u.Update(UpdateContext{Delta: dt, Input: in, Screen: world, Spawner: &spawned, TurnRamp: ramp})
+		turned += math.Remainder(u.Angle-before, 2*math.Pi)
+		spawned = spawned[:0]
+	}
+	return turned
+}
+
+func TestShipKinematicsAcrossTickLengths(t *testing.T) {
+	measures := []struct {
+		name      string
+		measure   func(next func(i int) time.Duration) float64
+		tolerance float64
+	}{
+		{"distance thrusting 2s from rest", func(next func(i int) time.Duration) float64 {
+			u := NewUser(50000, 50000)
+			u.Angle = 0
+			flyShip(u, Input{Up: true}, 2*time.Second, next)
+			return u.X - 50000
+		}, 0.2},
+		{"speed after thrusting 2s", func(next func(i int) time.Duration) float64 {
+			u := NewUser(50000, 50000)
+			u.Angle = 0
+			flyShip(u, Input{Up: true}, 2*time.Second, next)
+			return math.Hypot(u.VX, u.VY)
+		}, 1e-9},
+		{"stopping distance from max speed", func(next func(i int) time.Duration) float64 {
+			u := NewUser(50000, 50000)
+			u.Angle, u.VX = 0, u.MaxSpeed
+			flyShip(u, Input{}, 20*time.Second, next)
+			return u.X - 50000
+		}, 0.05},
+		{"angle turned in 1s", func(next func(i int) time.Duration) float64 {
+			u := NewUser(50000, 50000)
+			return -flyShip(u, Input{Left: true}, time.Second, next)
+		}, 0.01},
+	}
+	for _, m := range measures {
+		var results []float64
+		for _, sched := range tickSchedules {
+			results = append(results, m.measure(sched.next))
+		}
+		if spread := slices.Max(results) - slices.Min(results); spread > m.tolerance {
+			t.Errorf("%s differs by %v across tick lengths (%v), want within %v", m.name, spread, results, m.tolerance)
+		}
+	}
+}
@@ -92,6 +92,30 @@ func (g *SpatialGrid) QueryAround(x, y float64, fn func(index int) bool) {
 	}
 }
 
+// QueryRect calls fn for each item index in the cells overlapping the
+// rectangle from (x0, y0) to (x1, y1). The rectangle may extend past the
+// world edges, which wrap; each cell is visited at most once even when the
+// rectangle is larger than the world. If fn returns true, iteration stops.
+func (g *SpatialGrid) QueryRect(x0, y0, x1, y1 float64, fn func(index int) bool) {
+	col0 := int(math.Floor(x0 * g.invCellSize))
+	row0 := int(math.Floor(y0 * g.invCellSize))
+	cols := min(int(math.Floor(x1*g.invCellSize))-col0+1, g.cols)
+	rows := min(int(math.Floor(y1*g.invCellSize))-row0+1, g.rows)
+
+	for dr := 0; dr < rows; dr++ {
+		r := ((row0+dr)%g.rows + g.rows) % g.rows
+		rowOffset := r * g.cols
+		for dc := 0; dc < cols; dc++ {
+			c := ((col0+dc)%g.cols + g.cols) % g.cols
+			for _, itemIdx := range g.cells[rowOffset+c].items {
+				if fn(itemIdx) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // posToCell converts world coordinates to grid cell coordinates.
 // Clamps to valid range to handle edge cases with floating point.
 func (g *SpatialGrid) posToCell(x, y float64) (col, row int) {
@@ -1,4 +1,4 @@
-// Package physics provides collision detection and distance utilities.
+// Package physics provides collision detection, distance and drag utilities.
 package physics
 
 import "math"
@@ -28,3 +28,47 @@ func CirclesOverlap(x1, y1, r1, x2, y2, r2 float64) bool {
 	minDist := r1 + r2
 	return DistanceSquared(x1, y1, x2, y2) < minDist*minDist
 }
+
+// DragModel selects how velocity decays under drag.
+type DragModel int
+
+const (
+	// DragExponential multiplies velocity by retain^steps. Splitting an
+	// interval into several shorter ticks gives the same result, so movement
+	// is identical at any tick rate.
+	DragExponential DragModel = iota
+	// DragLinear multiplies velocity by 1 - (1-retain)*steps, a first-order
+	// approximation of exponential decay. Cheaper, but it decays slightly
+	// less at higher tick rates and clamps to a full stop for large steps.
+	DragLinear
+)
+
+// DragModelByName returns the drag model with the given name, "exponential"
+// or "linear". An empty name selects DragExponential.
+func DragModelByName(name string) (DragModel, bool) {
+	switch name {
+	case "", "exponential":
+		return DragExponential, true
+	case "linear":
+		return DragLinear, true
+	}
+	return DragExponential, false
+}
+
+// DragFactor returns the factor to multiply velocity by after a tick.
+// retain is the fraction of velocity kept after one drag period (1.0 = no
+// drag), and steps is the tick length measured in drag periods (e.g. dt for
+// a per-second retain, dt*60 for a per-frame-at-60Hz retain).
+func DragFactor(model DragModel, retain, steps float64) float64 {
+	if model == DragLinear {
+		f := 1.0 - (1.0-retain)*steps
+		if f < 0 {
+			return 0
+		}
+		return f
+	}
+	if retain <= 0 {
+		return 0
+	}
+	return math.Pow(retain, steps)
+}
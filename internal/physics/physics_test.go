@@ -0,0 +1,66 @@
+package physics
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// dragOverOneSecond applies drag to a unit speed for one second in ticks of
+// 1/fps seconds, with retain measured per second.
+func dragOverOneSecond(model DragModel, retain float64, fps int) float64 {
+	v := 1.0
+	dt := 1 / float64(fps)
+	for range fps {
+		v *= DragFactor(model, retain, dt)
+	}
+	return v
+}
+
+func TestDragIsTickRateIndependent(t *testing.T) {
+	tests := []struct {
+		name   string
+		model  DragModel
+		exact  float64 // Largest difference from retain after one second
+		spread float64 // Largest difference between tick rates
+	}{
+		{"exponential", DragExponential, 1e-9, 1e-9},
+		// First-order: decays less than exact, but nearly alike at any rate
+		{"linear", DragLinear, 0.11, 0.005},
+	}
+	for _, tt := range tests {
+		for _, retain := range []float64{0.5, 0.9, 0.99} {
+			var speeds []float64
+			for _, fps := range []int{30, 60, 144} {
+				speeds = append(speeds, dragOverOneSecond(tt.model, retain, fps))
+			}
+			for _, v := range speeds {
+				if math.Abs(v-retain) > tt.exact {
+					t.Errorf("%s, retain %v: speeds after 1s at 30/60/144 fps = %v, want %v", tt.name, retain, speeds, retain)
+					break
+				}
+			}
+			if spread := slices.Max(speeds) - slices.Min(speeds); spread > tt.spread {
+				t.Errorf("%s, retain %v: speeds at 30/60/144 fps = %v differ by %v", tt.name, retain, speeds, spread)
+			}
+		}
+	}
+}
+
+func TestDragModelByName(t *testing.T) {
+	tests := []struct {
+		name string
+		want DragModel
+		ok   bool
+	}{
+		{"", DragExponential, true},
+		{"exponential", DragExponential, true},
+		{"linear", DragLinear, true},
+		{"quadratic", DragExponential, false},
+	}
+	for _, tt := range tests {
+		if got, ok := DragModelByName(tt.name); got != tt.want || ok != tt.ok {
+			t.Errorf("DragModelByName(%q) = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}